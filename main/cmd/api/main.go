@@ -3,17 +3,27 @@ package main
 import (
 	"context"
 	"log"
+	"time"
+	_ "time/tzdata" // club time zones must resolve in minimal containers
 
 	firebase "firebase.google.com/go/v4"
 	"google.golang.org/api/option"
 
 	"main/internal/application/usecase"
+	"main/internal/config"
+	"main/internal/infrastructure/audit"
+	"main/internal/infrastructure/firebaseauth"
 	fsrepo "main/internal/infrastructure/firestore"
+	"main/internal/infrastructure/scheduler"
+	"main/internal/infrastructure/stripeclient"
 	"main/internal/interfaces/http"
 	"main/internal/interfaces/http/handler"
 )
 
 func main() {
+	// load config
+	config.Init()
+
 	// Initialize Firebase App
 	opt := option.WithCredentialsFile("/main/firebase.json")
 	app, err := firebase.NewApp(context.Background(), nil, opt)
@@ -34,25 +44,92 @@ func main() {
 		log.Fatalf("error initializing auth: %v", err)
 	}
 
+	// Audit log; writes to the repositories wrapped below are recorded
+	retention := time.Duration(config.Cfg.Audit.RetentionDays) * 24 * time.Hour
+	auditUC := usecase.NewAuditUseCase(fsrepo.NewAuditRepoFS(fsClient), retention)
+
 	// Repositories
-	clubRepo := fsrepo.NewClubRepoFS(fsClient)
-	compRepo := fsrepo.NewComputerRepoFS(fsClient)
-	bookRepo := fsrepo.NewBookingRepoFS(fsClient)
+	clubRepo := audit.Clubs(fsrepo.NewClubRepoFS(fsClient), auditUC)
+	compRepo := audit.Computers(fsrepo.NewComputerRepoFS(fsClient), auditUC)
+	bookRepo := audit.Bookings(fsrepo.NewBookingRepoFS(fsClient), auditUC)
+	promoRepo := fsrepo.NewPromoCodeRepoFS(fsClient)
+	walletRepo := audit.Wallets(fsrepo.NewWalletRepoFS(fsClient), auditUC)
+	memberRepo := fsrepo.NewMembershipRepoFS(fsClient)
+	loyaltyRepo := fsrepo.NewLoyaltyRepoFS(fsClient)
+	invoiceRepo := fsrepo.NewInvoiceRepoFS(fsClient)
+	ledgerRepo := audit.Payments(fsrepo.NewPaymentLedgerRepoFS(fsClient), auditUC)
+	floorRepo := fsrepo.NewFloorPlanRepoFS(fsClient)
+	alertRepo := fsrepo.NewAlertRepoFS(fsClient)
+	commandRepo := fsrepo.NewAgentCommandRepoFS(fsClient)
+	keyRepo := fsrepo.NewAPIKeyRepoFS(fsClient)
+	userRepo := fsrepo.NewUserRepoFS(fsClient)
+	banRepo := fsrepo.NewBanRepoFS(fsClient)
+	roleStore := audit.Roles(firebaseauth.NewRoleStore(authClient), auditUC)
 
 	// Use Cases
-	clubUC := usecase.NewClubUseCase(clubRepo)
-	compUC := usecase.NewComputerUseCase(compRepo)
-	bookUC := usecase.NewBookingUseCase(bookRepo, compRepo)
-	paymentUC := usecase.NewPaymentUseCase()
+	compUC := usecase.NewComputerUseCase(compRepo, clubRepo, bookRepo)
+	promoUC := usecase.NewPromoCodeUseCase(promoRepo, bookRepo)
+	ledgerUC := usecase.NewPaymentLedgerUseCase(ledgerRepo, stripeclient.NewGateway())
+	walletUC := usecase.NewWalletUseCase(walletRepo, ledgerUC)
+	memberUC := usecase.NewMembershipUseCase(memberRepo)
+	loyaltyUC := usecase.NewLoyaltyUseCase(loyaltyRepo)
+	invoiceUC := usecase.NewInvoiceUseCase(invoiceRepo, clubRepo)
+	commandUC := usecase.NewAgentCommandUseCase(commandRepo, compRepo)
+	userUC := usecase.NewUserUseCase(userRepo, clubRepo, roleStore)
+	banUC := usecase.NewBanUseCase(banRepo, clubRepo, bookRepo)
+	pricingUC := usecase.NewPricingUseCase(clubRepo, compRepo, promoUC, memberUC, loyaltyUC)
+	bookUC := usecase.NewBookingUseCase(
+		bookRepo, compRepo, clubRepo,
+		pricingUC, promoUC, walletUC, memberUC, loyaltyUC, invoiceUC,
+		ledgerUC, commandUC, userUC, banUC,
+	)
+	purgeAfter := time.Duration(config.Cfg.Clubs.PurgeAfterDays) * 24 * time.Hour
+	clubUC := usecase.NewClubUseCase(clubRepo, compRepo, bookRepo, floorRepo, keyRepo, bookUC, purgeAfter)
+	paymentUC := usecase.NewPaymentUseCase(walletUC, memberUC, bookUC, ledgerUC)
+	reportUC := usecase.NewReportUseCase(bookRepo, ledgerRepo, clubRepo, userUC)
+	floorUC := usecase.NewFloorPlanUseCase(floorRepo, clubRepo, compRepo, bookRepo)
+	keyUC := usecase.NewAPIKeyUseCase(keyRepo, clubRepo, compRepo)
+	agentUC := usecase.NewAgentUseCase(compRepo, alertRepo, bookRepo, keyRepo, keyUC)
 
 	// Handlers
 	clubH := handler.NewClubHandler(clubUC)
 	compH := handler.NewComputerHandler(compUC)
-	bookH := handler.NewBookingHandler(bookUC, clubUC)
-	authH := handler.NewAuthHandler(authClient)
+	bookH := handler.NewBookingHandler(bookUC)
+	authH := handler.NewAuthHandler(authClient, userUC)
 	paymentH := handler.NewPaymentHandler(paymentUC)
+	promoH := handler.NewPromoCodeHandler(promoUC)
+	walletH := handler.NewWalletHandler(walletUC)
+	memberH := handler.NewMembershipHandler(memberUC)
+	loyaltyH := handler.NewLoyaltyHandler(loyaltyUC)
+	invoiceH := handler.NewInvoiceHandler(invoiceUC)
+	reportH := handler.NewReportHandler(reportUC, clubUC)
+	ledgerH := handler.NewPaymentLedgerHandler(ledgerUC)
+	floorH := handler.NewFloorPlanHandler(floorUC, clubUC)
+	agentH := handler.NewAgentHandler(agentUC)
+	commandH := handler.NewAgentCommandHandler(commandUC)
+	keyH := handler.NewAPIKeyHandler(keyUC)
+	userH := handler.NewUserHandler(userUC)
+	banH := handler.NewBanHandler(banUC)
+	auditH := handler.NewAuditHandler(auditUC)
+
+	// Background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	scheduler.Every(jobsCtx, time.Hour, "expire-loyalty-points", loyaltyUC.ExpirePoints)
+	scheduler.Every(jobsCtx, 24*time.Hour, "reconcile-payments", ledgerUC.ReconcileYesterday)
+	scheduler.Every(jobsCtx, 30*time.Second, "sweep-offline-agents", agentUC.SweepOffline)
+	scheduler.Every(jobsCtx, time.Minute, "expire-agent-commands", commandUC.ExpireStale)
+	scheduler.Every(jobsCtx, 10*time.Minute, "sweep-no-shows", banUC.SweepNoShows)
+	scheduler.Every(jobsCtx, 24*time.Hour, "purge-audit-log", auditUC.Purge)
+	scheduler.Every(jobsCtx, 24*time.Hour, "purge-deleted-clubs", clubUC.PurgeDeleted)
 
 	// Router setup
-	router := http.NewRouter(clubH, compH, bookH, authH, paymentH, authClient)
+	router := http.NewRouter(
+		clubH, compH, bookH, authH, paymentH,
+		promoH, walletH, memberH, loyaltyH, invoiceH,
+		reportH, ledgerH, floorH,
+		agentH, commandH, keyH, userH, banH, auditH, keyUC, compUC, clubUC, keyUC,
+		authClient,
+	)
 	log.Fatal(router.Run(":8080"))
 }
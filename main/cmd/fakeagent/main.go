@@ -0,0 +1,37 @@
+// Command fakeagent plays the agent of one club PC against a running API,
+// for trying the command queue without real hardware. It sends heartbeats,
+// long-polls for commands, logs them and acknowledges each one.
+//
+//	go run ./cmd/fakeagent -token ck_... [-api http://localhost:8080] [-fail reboot]
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"main/internal/fakeagent"
+)
+
+func main() {
+	api := flag.String("api", "http://localhost:8080", "API base URL")
+	token := flag.String("token", "", "agent API key issued for the computer")
+	fail := flag.String("fail", "", "command kind to acknowledge as failed")
+	flag.Parse()
+	if *token == "" {
+		log.Fatal("-token is required")
+	}
+	a := fakeagent.New(*api, *token)
+	a.Fail = *fail
+
+	// a poll waits at most 25s, well inside the offline threshold
+	for {
+		if err := a.Heartbeat(); err != nil {
+			log.Printf("heartbeat: %v", err)
+		}
+		if _, err := a.Step(25 * time.Second); err != nil {
+			log.Printf("poll: %v", err)
+			time.Sleep(5 * time.Second)
+		}
+	}
+}
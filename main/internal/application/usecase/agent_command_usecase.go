@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+const (
+	// MaxCommandWait bounds how long an agent's poll may wait for commands.
+	MaxCommandWait = 30 * time.Second
+	// commandPollInterval is how often a waiting poll checks the queue.
+	commandPollInterval = 2 * time.Second
+	// commandHistory is how many recent commands staff see per computer.
+	commandHistory = 50
+)
+
+// AgentCommandUseCase defines business logic for the command queue of each
+// club PC. Booking transitions queue session commands; staff can send any
+// command by hand.
+type AgentCommandUseCase interface {
+	// Send queues a command for a computer now on behalf of staffID.
+	Send(ctx context.Context, staffID, computerID, kind, message string) (*entities.AgentCommand, error)
+	// StartSession unlocks the booked computer now and schedules the
+	// end-of-session warning and lock.
+	StartSession(ctx context.Context, b *entities.Booking) error
+	// EndSession cancels the booking's queued commands and locks the
+	// computer if its session is still running.
+	EndSession(ctx context.Context, b *entities.Booking) error
+	// Poll returns the commands due for comp, waiting up to wait for one to
+	// become due. Returned commands count as delivered.
+	Poll(ctx context.Context, comp *entities.Computer, wait time.Duration) ([]*entities.AgentCommand, error)
+	// Ack records the outcome reported by the agent; errMsg marks it failed.
+	Ack(ctx context.Context, comp *entities.Computer, id, errMsg string) (*entities.AgentCommand, error)
+	// History returns the latest commands of a computer.
+	History(ctx context.Context, computerID string) ([]*entities.AgentCommand, error)
+	// ExpireStale closes commands that were not run in time. It runs as a
+	// background job.
+	ExpireStale(ctx context.Context) error
+}
+
+type agentCommandInteractor struct {
+	repo     repository.AgentCommandRepository
+	compRepo repository.ComputerRepository
+}
+
+// NewAgentCommandUseCase constructs a new AgentCommandUseCase with the given repositories.
+func NewAgentCommandUseCase(r repository.AgentCommandRepository, compRepo repository.ComputerRepository) AgentCommandUseCase {
+	return &agentCommandInteractor{repo: r, compRepo: compRepo}
+}
+
+func (u *agentCommandInteractor) Send(ctx context.Context, staffID, computerID, kind, message string) (*entities.AgentCommand, error) {
+	if !entities.IsValidCommand(kind) {
+		return nil, fmt.Errorf("%w: %s", entities.ErrInvalidCommand, kind)
+	}
+	comp, err := u.compRepo.FindByID(ctx, computerID)
+	if err != nil {
+		return nil, err
+	}
+	return u.enqueue(ctx, comp, "", kind, message, time.Now(), staffID)
+}
+
+func (u *agentCommandInteractor) StartSession(ctx context.Context, b *entities.Booking) error {
+	comp, err := u.bookedComputer(ctx, b)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if _, err := u.enqueue(ctx, comp, b.ID, entities.CommandUnlock, "", now, "booking"); err != nil {
+		return err
+	}
+	if warnAt := b.EndTime.Add(-entities.SessionWarning); warnAt.After(now) {
+		msg := fmt.Sprintf("Your session ends in %d minutes", int(entities.SessionWarning.Minutes()))
+		if _, err := u.enqueue(ctx, comp, b.ID, entities.CommandWarn, msg, warnAt, "booking"); err != nil {
+			return err
+		}
+	}
+	_, err = u.enqueue(ctx, comp, b.ID, entities.CommandLock, "", b.EndTime, "booking")
+	return err
+}
+
+func (u *agentCommandInteractor) EndSession(ctx context.Context, b *entities.Booking) error {
+	open, err := u.repo.FindOpenByBooking(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+	// a command a poll delivers in the meantime stays delivered
+	for _, cmd := range open {
+		status, attempts := cmd.Status, cmd.Attempts
+		cmd.Status = entities.CommandCancelled
+		if _, err := u.repo.Claim(ctx, cmd, status, attempts); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	if b.CheckedInAt.IsZero() || !b.EndTime.After(now) {
+		return nil
+	}
+	comp, err := u.bookedComputer(ctx, b)
+	if err != nil {
+		return err
+	}
+	_, err = u.enqueue(ctx, comp, b.ID, entities.CommandLock, "", now, "booking")
+	return err
+}
+
+func (u *agentCommandInteractor) Poll(ctx context.Context, comp *entities.Computer, wait time.Duration) ([]*entities.AgentCommand, error) {
+	if wait > MaxCommandWait {
+		wait = MaxCommandWait
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		due, err := u.deliver(ctx, comp.ID)
+		if err != nil || len(due) > 0 || !time.Now().Add(commandPollInterval).Before(deadline) {
+			return due, err
+		}
+		select {
+		case <-ctx.Done():
+			return due, nil
+		case <-time.After(commandPollInterval):
+		}
+	}
+}
+
+// deliver marks the due commands of a computer delivered and returns them,
+// closing the ones that ran out of time or attempts on the way. Commands
+// another poll changed in the meantime are left to it.
+func (u *agentCommandInteractor) deliver(ctx context.Context, computerID string) ([]*entities.AgentCommand, error) {
+	open, err := u.repo.FindOpenByComputer(ctx, computerID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	due := make([]*entities.AgentCommand, 0)
+	for _, cmd := range open {
+		status, attempts := cmd.Status, cmd.Attempts
+		switch {
+		case !now.Before(cmd.ExpiresAt):
+			cmd.Status = entities.CommandExpired
+		case cmd.Status == entities.CommandDelivered && cmd.Attempts >= cmd.MaxAttempts &&
+			!now.Before(cmd.DeliveredAt.Add(entities.CommandAckTimeout)):
+			cmd.Status = entities.CommandFailed
+			cmd.Error = "not acknowledged"
+		case cmd.Due(now):
+			cmd.Status = entities.CommandDelivered
+			cmd.Attempts++
+			cmd.DeliveredAt = now
+		default:
+			continue
+		}
+		claimed, err := u.repo.Claim(ctx, cmd, status, attempts)
+		if err != nil {
+			return nil, err
+		}
+		if claimed && cmd.Status == entities.CommandDelivered {
+			due = append(due, cmd)
+		}
+	}
+	return due, nil
+}
+
+// Ack reads the command again when a poll redelivered or closed it after
+// it was read, so the outcome is recorded against its current state.
+func (u *agentCommandInteractor) Ack(ctx context.Context, comp *entities.Computer, id, errMsg string) (*entities.AgentCommand, error) {
+	for {
+		cmd, err := u.repo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if cmd.ComputerID != comp.ID {
+			return nil, entities.ErrCommandNotFound
+		}
+		if cmd.Status != entities.CommandDelivered {
+			return nil, entities.ErrCommandClosed
+		}
+		attempts := cmd.Attempts
+		cmd.Status = entities.CommandAcked
+		if errMsg != "" {
+			cmd.Status = entities.CommandFailed
+			cmd.Error = errMsg
+		}
+		cmd.AckedAt = time.Now()
+		claimed, err := u.repo.Claim(ctx, cmd, entities.CommandDelivered, attempts)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return cmd, nil
+		}
+	}
+}
+
+func (u *agentCommandInteractor) History(ctx context.Context, computerID string) ([]*entities.AgentCommand, error) {
+	list, err := u.repo.FindByComputer(ctx, computerID, commandHistory)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = make([]*entities.AgentCommand, 0)
+	}
+	return list, nil
+}
+
+func (u *agentCommandInteractor) ExpireStale(ctx context.Context) error {
+	list, err := u.repo.FindOpenExpiredBefore(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	// commands acked or cancelled in the meantime keep their outcome
+	for _, cmd := range list {
+		status, attempts := cmd.Status, cmd.Attempts
+		cmd.Status = entities.CommandExpired
+		if _, err := u.repo.Claim(ctx, cmd, status, attempts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *agentCommandInteractor) enqueue(ctx context.Context, comp *entities.Computer, bookingID, kind, message string, at time.Time, by string) (*entities.AgentCommand, error) {
+	cmd := &entities.AgentCommand{
+		ComputerID:  comp.ID,
+		ClubID:      comp.ClubID,
+		BookingID:   bookingID,
+		Kind:        kind,
+		Message:     message,
+		Status:      entities.CommandPending,
+		NotBefore:   at,
+		ExpiresAt:   at.Add(entities.CommandTTL),
+		MaxAttempts: entities.CommandMaxAttempts,
+		CreatedBy:   by,
+		CreatedAt:   time.Now(),
+	}
+	if err := u.repo.Create(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (u *agentCommandInteractor) bookedComputer(ctx context.Context, b *entities.Booking) (*entities.Computer, error) {
+	comps, err := u.compRepo.FindByClub(ctx, b.ClubID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comps {
+		if c.PCNumber == b.PCNumber {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: pc %d", ErrUnknownComputer, b.PCNumber)
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+// AgentUseCase defines business logic for the agents running on club PCs
+// and the alerts raised from what they report.
+type AgentUseCase interface {
+	// IssueToken creates the agent API key of a computer and revokes its
+	// previous ones. The key is shown once.
+	IssueToken(ctx context.Context, createdBy, computerID string) (string, error)
+	// Heartbeat records a report from the agent of comp and resolves its
+	// offline alerts if it was offline.
+	Heartbeat(ctx context.Context, comp *entities.Computer, hb entities.Heartbeat) (*entities.AgentStatus, error)
+	// SweepOffline marks silent agents offline and raises an alert for each
+	// one whose computer is booked right now. It runs as a background job.
+	SweepOffline(ctx context.Context) error
+	Alerts(ctx context.Context, clubID string) ([]*entities.Alert, error)
+	ResolveAlert(ctx context.Context, by *entities.Principal, id string) (*entities.Alert, error)
+}
+
+type agentInteractor struct {
+	compRepo    repository.ComputerRepository
+	alertRepo   repository.AlertRepository
+	bookingRepo repository.BookingRepository
+	keyRepo     repository.APIKeyRepository
+	keyUC       APIKeyUseCase
+}
+
+// NewAgentUseCase constructs a new AgentUseCase with the given repositories.
+func NewAgentUseCase(
+	compRepo repository.ComputerRepository,
+	alertRepo repository.AlertRepository,
+	bookingRepo repository.BookingRepository,
+	keyRepo repository.APIKeyRepository,
+	keyUC APIKeyUseCase,
+) AgentUseCase {
+	return &agentInteractor{
+		compRepo:    compRepo,
+		alertRepo:   alertRepo,
+		bookingRepo: bookingRepo,
+		keyRepo:     keyRepo,
+		keyUC:       keyUC,
+	}
+}
+
+func (u *agentInteractor) IssueToken(ctx context.Context, createdBy, computerID string) (string, error) {
+	comp, err := u.compRepo.FindByID(ctx, computerID)
+	if err != nil {
+		return "", err
+	}
+	old, err := u.keyRepo.FindByComputer(ctx, computerID)
+	if err != nil {
+		return "", err
+	}
+	for _, k := range old {
+		if !k.HasScope(entities.ScopeAgent) || !k.RevokedAt.IsZero() {
+			continue
+		}
+		if _, err := u.keyUC.Revoke(ctx, k.ID); err != nil {
+			return "", err
+		}
+	}
+	_, raw, err := u.keyUC.Create(ctx, createdBy, &entities.APIKey{
+		ClubID:     comp.ClubID,
+		Name:       fmt.Sprintf("PC %d agent", comp.PCNumber),
+		Scopes:     []string{entities.ScopeAgent},
+		ComputerID: comp.ID,
+	})
+	return raw, err
+}
+
+func (u *agentInteractor) Heartbeat(ctx context.Context, comp *entities.Computer, hb entities.Heartbeat) (*entities.AgentStatus, error) {
+	wasOnline := comp.Agent != nil && comp.Agent.Online
+	st := &entities.AgentStatus{
+		Online:      true,
+		LastSeen:    time.Now(),
+		SessionUser: hb.SessionUser,
+		CPUTempC:    hb.CPUTempC,
+		GPUTempC:    hb.GPUTempC,
+		UptimeSec:   hb.UptimeSec,
+	}
+	if err := u.compRepo.SaveAgentStatus(ctx, comp.ID, st); err != nil {
+		return nil, err
+	}
+	if wasOnline {
+		return st, nil
+	}
+	alerts, err := u.alertRepo.FindOpenByComputer(ctx, comp.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range alerts {
+		if a.Kind != entities.AlertPCOffline {
+			continue
+		}
+		if err := u.resolve(ctx, a, "agent"); err != nil {
+			return nil, err
+		}
+	}
+	return st, nil
+}
+
+func (u *agentInteractor) SweepOffline(ctx context.Context) error {
+	now := time.Now()
+	comps, err := u.compRepo.FindAgentsSilentSince(ctx, now.Add(-entities.AgentOfflineAfter))
+	if err != nil {
+		return err
+	}
+	for _, comp := range comps {
+		comp.Agent.Online = false
+		if err := u.compRepo.SaveAgentStatus(ctx, comp.ID, comp.Agent); err != nil {
+			return err
+		}
+		b, err := u.currentBooking(ctx, comp, now)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			continue
+		}
+		alert := &entities.Alert{
+			ClubID:     comp.ClubID,
+			ComputerID: comp.ID,
+			PCNumber:   comp.PCNumber,
+			BookingID:  b.ID,
+			Kind:       entities.AlertPCOffline,
+			Message:    fmt.Sprintf("PC %d went offline during a booking; last seen %s", comp.PCNumber, comp.Agent.LastSeen.Format(time.RFC3339)),
+			Open:       true,
+			CreatedAt:  now,
+		}
+		if err := u.alertRepo.Create(ctx, alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentBooking returns the booking holding comp at now, if any.
+func (u *agentInteractor) currentBooking(ctx context.Context, comp *entities.Computer, now time.Time) (*entities.Booking, error) {
+	bookings, err := u.bookingRepo.FindByClub(ctx, comp.ClubID, now.Add(-maxBookingSpan), now.Add(time.Second))
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bookings {
+		if b.Holds() && b.PCNumber == comp.PCNumber && b.Overlaps(now, now.Add(time.Second)) {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *agentInteractor) Alerts(ctx context.Context, clubID string) ([]*entities.Alert, error) {
+	list, err := u.alertRepo.FindOpenByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = make([]*entities.Alert, 0)
+	}
+	return list, nil
+}
+
+func (u *agentInteractor) ResolveAlert(ctx context.Context, by *entities.Principal, id string) (*entities.Alert, error) {
+	a, err := u.alertRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !by.CanAccessClub(a.ClubID) {
+		return nil, entities.ErrAlertOtherClub
+	}
+	if !a.Open {
+		return a, nil
+	}
+	if err := u.resolve(ctx, a, by.UID); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (u *agentInteractor) resolve(ctx context.Context, a *entities.Alert, by string) error {
+	a.Open = false
+	a.ResolvedAt = time.Now()
+	a.ResolvedBy = by
+	return u.alertRepo.Update(ctx, a)
+}
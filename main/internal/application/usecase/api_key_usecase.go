@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"strings"
+	"time"
+)
+
+// lastUsedPrecision limits how often authentication records key use.
+const lastUsedPrecision = time.Minute
+
+// APIKeyUseCase defines business logic for the API keys of machine clients
+// such as front-desk kiosks and PC agents. Keys look like
+// ck_<prefix>_<secret>; only the prefix and a hash are stored.
+type APIKeyUseCase interface {
+	// Create issues a key and returns it with its secret, which is not
+	// shown again.
+	Create(ctx context.Context, createdBy string, k *entities.APIKey) (*entities.APIKey, string, error)
+	GetByID(ctx context.Context, id string) (*entities.APIKey, error)
+	List(ctx context.Context, clubID string) ([]*entities.APIKey, error)
+	// Rotate issues a replacement with the same scopes; the old key keeps
+	// working for APIKeyRotationGrace.
+	Rotate(ctx context.Context, by, id string) (*entities.APIKey, string, error)
+	Revoke(ctx context.Context, id string) (*entities.APIKey, error)
+	// Authenticate returns the principal of a raw key.
+	Authenticate(ctx context.Context, raw string) (*entities.Principal, error)
+}
+
+type apiKeyInteractor struct {
+	repo     repository.APIKeyRepository
+	clubRepo repository.ClubRepository
+	compRepo repository.ComputerRepository
+}
+
+// NewAPIKeyUseCase constructs a new APIKeyUseCase with the given repositories.
+func NewAPIKeyUseCase(
+	r repository.APIKeyRepository,
+	clubRepo repository.ClubRepository,
+	compRepo repository.ComputerRepository,
+) APIKeyUseCase {
+	return &apiKeyInteractor{repo: r, clubRepo: clubRepo, compRepo: compRepo}
+}
+
+func (u *apiKeyInteractor) Create(ctx context.Context, createdBy string, k *entities.APIKey) (*entities.APIKey, string, error) {
+	if _, err := u.clubRepo.FindByID(ctx, k.ClubID); err != nil {
+		return nil, "", ErrInvalidClub
+	}
+	if len(k.Scopes) == 0 {
+		return nil, "", entities.ErrInvalidScope
+	}
+	for _, s := range k.Scopes {
+		if !entities.IsValidScope(s) {
+			return nil, "", fmt.Errorf("%w: %s", entities.ErrInvalidScope, s)
+		}
+	}
+	// an agent key speaks for exactly one computer
+	if k.HasScope(entities.ScopeAgent) != (k.ComputerID != "") {
+		return nil, "", fmt.Errorf("%w: the agent scope needs a computer_id and only it may have one", entities.ErrInvalidScope)
+	}
+	if k.ComputerID != "" {
+		comp, err := u.compRepo.FindByID(ctx, k.ComputerID)
+		if err != nil || comp.ClubID != k.ClubID {
+			return nil, "", fmt.Errorf("%w: %s", ErrUnknownComputer, k.ComputerID)
+		}
+	}
+	now := time.Now()
+	if !k.ExpiresAt.IsZero() && !k.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: expires_at is in the past", entities.ErrInvalidAPIKey)
+	}
+	raw, prefix, err := newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	k.Prefix = prefix
+	k.Hash = hashToken(raw)
+	k.CreatedBy = createdBy
+	k.CreatedAt = now
+	k.LastUsedAt = time.Time{}
+	k.RevokedAt = time.Time{}
+	k.ReplacedBy = ""
+	if err := u.repo.Create(ctx, k); err != nil {
+		return nil, "", err
+	}
+	return k, raw, nil
+}
+
+func (u *apiKeyInteractor) GetByID(ctx context.Context, id string) (*entities.APIKey, error) {
+	return u.repo.FindByID(ctx, id)
+}
+
+func (u *apiKeyInteractor) List(ctx context.Context, clubID string) ([]*entities.APIKey, error) {
+	list, err := u.repo.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = make([]*entities.APIKey, 0)
+	}
+	return list, nil
+}
+
+func (u *apiKeyInteractor) Rotate(ctx context.Context, by, id string) (*entities.APIKey, string, error) {
+	old, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if !old.Active(now) {
+		return nil, "", entities.ErrAPIKeyRevoked
+	}
+	next := &entities.APIKey{
+		ClubID:     old.ClubID,
+		Name:       old.Name,
+		Scopes:     old.Scopes,
+		ComputerID: old.ComputerID,
+	}
+	next, raw, err := u.Create(ctx, by, next)
+	if err != nil {
+		return nil, "", err
+	}
+	old.ReplacedBy = next.ID
+	if grace := now.Add(entities.APIKeyRotationGrace); old.ExpiresAt.IsZero() || grace.Before(old.ExpiresAt) {
+		old.ExpiresAt = grace
+	}
+	if err := u.repo.Update(ctx, old); err != nil {
+		return nil, "", err
+	}
+	return next, raw, nil
+}
+
+func (u *apiKeyInteractor) Revoke(ctx context.Context, id string) (*entities.APIKey, error) {
+	k, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !k.RevokedAt.IsZero() {
+		return k, nil
+	}
+	k.RevokedAt = time.Now()
+	if err := u.repo.Update(ctx, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (u *apiKeyInteractor) Authenticate(ctx context.Context, raw string) (*entities.Principal, error) {
+	prefix, ok := apiKeyPrefix(raw)
+	if !ok {
+		return nil, entities.ErrInvalidAPIKey
+	}
+	k, err := u.repo.FindByPrefix(ctx, prefix)
+	if errors.Is(err, entities.ErrAPIKeyNotFound) {
+		return nil, entities.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(k.Hash), []byte(hashToken(raw))) != 1 || !k.Active(now) {
+		return nil, entities.ErrInvalidAPIKey
+	}
+	if now.Sub(k.LastUsedAt) > lastUsedPrecision {
+		if err := u.repo.Touch(ctx, k.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return &entities.Principal{
+		Kind:       entities.PrincipalAPIKey,
+		UID:        "key:" + k.ID,
+		KeyID:      k.ID,
+		ClubID:     k.ClubID,
+		ComputerID: k.ComputerID,
+		Scopes:     k.Scopes,
+	}, nil
+}
+
+// newAPIKey returns a fresh key and its public prefix.
+func newAPIKey() (raw, prefix string, err error) {
+	id := make([]byte, 4)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	prefix = entities.APIKeyTag + hex.EncodeToString(id)
+	return prefix + "_" + hex.EncodeToString(secret), prefix, nil
+}
+
+// apiKeyPrefix returns the public part of a raw key.
+func apiKeyPrefix(raw string) (string, bool) {
+	if !strings.HasPrefix(raw, entities.APIKeyTag) {
+		return "", false
+	}
+	i := strings.Index(raw[len(entities.APIKeyTag):], "_")
+	if i <= 0 {
+		return "", false
+	}
+	return raw[:len(entities.APIKeyTag)+i], true
+}
+
+// hashToken returns the stored form of a machine credential.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// AuditUseCase defines business logic for the audit log.
+type AuditUseCase interface {
+	// Record logs a change of an entity by the actor carried in ctx. before
+	// is nil for a created entity and after is nil for a deleted one; an
+	// update that changes nothing is not logged. A failure to write the
+	// entry is logged rather than returned, since the change has happened.
+	Record(ctx context.Context, action, entityType, entityID string, before, after interface{})
+	Search(ctx context.Context, f entities.AuditFilter) (*entities.AuditPage, error)
+	// Purge deletes entries older than the retention period.
+	Purge(ctx context.Context) error
+}
+
+type auditInteractor struct {
+	repo      repository.AuditRepository
+	retention time.Duration
+}
+
+// NewAuditUseCase constructs a new AuditUseCase. Entries are kept for
+// retention; zero keeps them forever.
+func NewAuditUseCase(r repository.AuditRepository, retention time.Duration) AuditUseCase {
+	return &auditInteractor{repo: r, retention: retention}
+}
+
+func (u *auditInteractor) Record(ctx context.Context, action, entityType, entityID string, before, after interface{}) {
+	changes := diff(snapshot(before), snapshot(after))
+	if len(changes) == 0 && action == entities.AuditUpdate {
+		return
+	}
+	e := &entities.AuditEntry{
+		At:         time.Now(),
+		Actor:      entities.ActorFrom(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+	}
+	// the request may be cancelled right after the change it audits
+	if err := u.repo.Create(context.WithoutCancel(ctx), e); err != nil {
+		log.Printf("audit: %s %s %s: %v", action, entityType, entityID, err)
+	}
+}
+
+func (u *auditInteractor) Search(ctx context.Context, f entities.AuditFilter) (*entities.AuditPage, error) {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return nil, fmt.Errorf("%w: from must be before to", entities.ErrInvalidAuditFilter)
+	}
+	f.Limit = pageSize(f.Limit)
+	list, next, err := u.repo.Search(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = make([]*entities.AuditEntry, 0)
+	}
+	return &entities.AuditPage{Items: list, NextCursor: next}, nil
+}
+
+func (u *auditInteractor) Purge(ctx context.Context) error {
+	if u.retention <= 0 {
+		return nil
+	}
+	n, err := u.repo.DeleteBefore(ctx, time.Now().Add(-u.retention))
+	if n > 0 {
+		log.Printf("audit: purged %d entries", n)
+	}
+	return err
+}
+
+// diff pairs the fields that differ between two snapshots.
+func diff(before, after map[string]interface{}) map[string]entities.Change {
+	changes := make(map[string]entities.Change)
+	for k, b := range before {
+		if a, ok := after[k]; !ok || !reflect.DeepEqual(a, b) {
+			changes[k] = entities.Change{Before: b, After: after[k]}
+		}
+	}
+	for k, a := range after {
+		if _, ok := before[k]; !ok {
+			changes[k] = entities.Change{After: a}
+		}
+	}
+	return changes
+}
+
+// snapshot flattens an entity into its stored fields, or nil for nil.
+func snapshot(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	m, _ := plain(reflect.ValueOf(v)).(map[string]interface{})
+	return m
+}
+
+// plain converts v into maps, slices and scalars keyed by firestore field
+// names, skipping fields that are not stored. Times are reduced to UTC at
+// the precision Firestore keeps and empty collections to nil, so a value
+// read back compares equal to the one written.
+func plain(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.UTC().Truncate(time.Microsecond)
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("firestore"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			out[name] = plain(v.Field(i))
+		}
+		return out
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		for _, k := range v.MapKeys() {
+			out[fmt.Sprint(k.Interface())] = plain(v.MapIndex(k))
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = plain(v.Index(i))
+		}
+		return out
+	}
+	return v.Interface()
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+// noShowLookback is how far back each no-show sweep looks for ended
+// bookings, so a few missed runs lose nothing.
+const noShowLookback = time.Hour
+
+// BanUseCase defines business logic for club and platform-wide bans.
+type BanUseCase interface {
+	// Ban bans b.UserID at b.ClubID, or everywhere when b.ClubID is empty.
+	// Only admins manage platform-wide bans and staff only their club's.
+	Ban(ctx context.Context, by *entities.Principal, b *entities.Ban) (*entities.Ban, error)
+	// Lift ends a ban early. Lifting a ban that is no longer in force
+	// changes nothing.
+	Lift(ctx context.Context, by *entities.Principal, id string) (*entities.Ban, error)
+	// ClubBans returns the bans in force at clubID, platform-wide ones for
+	// an empty clubID.
+	ClubBans(ctx context.Context, clubID string) ([]*entities.Ban, error)
+	// UserBans returns the user's full ban history, newest first.
+	UserBans(ctx context.Context, userID string) ([]*entities.Ban, error)
+	// Check returns an error wrapping ErrUserBanned if a ban keeps the
+	// user from clubID.
+	Check(ctx context.Context, userID, clubID string) error
+	// SweepNoShows marks bookings that ended without a check-in as no-shows
+	// at clubs with a no-show policy, and bans users who reach its limit.
+	SweepNoShows(ctx context.Context) error
+}
+
+type banInteractor struct {
+	repo     repository.BanRepository
+	clubRepo repository.ClubRepository
+	bookRepo repository.BookingRepository
+}
+
+// NewBanUseCase constructs a new BanUseCase with the given repositories.
+func NewBanUseCase(
+	r repository.BanRepository,
+	clubRepo repository.ClubRepository,
+	bookRepo repository.BookingRepository,
+) BanUseCase {
+	return &banInteractor{repo: r, clubRepo: clubRepo, bookRepo: bookRepo}
+}
+
+func (u *banInteractor) Ban(ctx context.Context, by *entities.Principal, b *entities.Ban) (*entities.Ban, error) {
+	now := time.Now()
+	if b.UserID == "" || b.Reason == "" || (!b.ExpiresAt.IsZero() && !b.ExpiresAt.After(now)) {
+		return nil, entities.ErrInvalidBan
+	}
+	if err := canManage(by, b); err != nil {
+		return nil, err
+	}
+	if !b.Global() {
+		if _, err := u.clubRepo.FindByID(ctx, b.ClubID); err != nil {
+			return nil, err
+		}
+	}
+	ban := &entities.Ban{
+		UserID:    b.UserID,
+		ClubID:    b.ClubID,
+		Reason:    b.Reason,
+		Source:    entities.BanSourceStaff,
+		BannedBy:  by.UID,
+		CreatedAt: now,
+		ExpiresAt: b.ExpiresAt,
+	}
+	if err := u.repo.Create(ctx, ban); err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (u *banInteractor) Lift(ctx context.Context, by *entities.Principal, id string) (*entities.Ban, error) {
+	b, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := canManage(by, b); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !b.ActiveAt(now) {
+		return b, nil
+	}
+	b.LiftedBy = by.UID
+	b.LiftedAt = now
+	if err := u.repo.Update(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// canManage refuses platform-wide bans to all but admins and other clubs'
+// bans to staff.
+func canManage(by *entities.Principal, b *entities.Ban) error {
+	if b.Global() {
+		if by.Role != entities.RoleAdmin {
+			return entities.ErrGlobalBanRequired
+		}
+		return nil
+	}
+	if !by.CanAccessClub(b.ClubID) {
+		return entities.ErrBanOtherClub
+	}
+	return nil
+}
+
+func (u *banInteractor) ClubBans(ctx context.Context, clubID string) ([]*entities.Ban, error) {
+	list, err := u.repo.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := make([]*entities.Ban, 0, len(list))
+	for _, b := range list {
+		if b.ActiveAt(now) {
+			active = append(active, b)
+		}
+	}
+	return active, nil
+}
+
+func (u *banInteractor) UserBans(ctx context.Context, userID string) ([]*entities.Ban, error) {
+	return u.repo.FindByUser(ctx, userID)
+}
+
+func (u *banInteractor) Check(ctx context.Context, userID, clubID string) error {
+	b, err := u.activeBan(ctx, userID, clubID)
+	if err != nil {
+		return err
+	}
+	if b != nil {
+		return b.Err()
+	}
+	return nil
+}
+
+// activeBan returns a ban in force for the user at clubID, preferring the
+// one that lasts longest, or nil.
+func (u *banInteractor) activeBan(ctx context.Context, userID, clubID string) (*entities.Ban, error) {
+	list, err := u.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var found *entities.Ban
+	for _, b := range list {
+		if !b.ActiveAt(now) || !b.Covers(clubID) {
+			continue
+		}
+		if b.ExpiresAt.IsZero() {
+			return b, nil
+		}
+		if found == nil || b.ExpiresAt.After(found.ExpiresAt) {
+			found = b
+		}
+	}
+	return found, nil
+}
+
+func (u *banInteractor) SweepNoShows(ctx context.Context) error {
+	now := time.Now()
+	ended, err := u.bookRepo.FindEndedBetween(ctx, now.Add(-noShowLookback), now)
+	if err != nil {
+		return err
+	}
+	policies := make(map[string]*entities.NoShowPolicy)
+	for _, b := range ended {
+		if b.NoShow || !b.CheckedInAt.IsZero() ||
+			(b.Status != entities.BookingConfirmed && b.Status != entities.BookingActive) {
+			continue
+		}
+		p, ok := policies[b.ClubID]
+		if !ok {
+			club, err := u.clubRepo.FindByID(ctx, b.ClubID)
+			// bookings of deleted clubs are left alone
+			if err != nil && !errors.Is(err, entities.ErrClubNotFound) {
+				return err
+			}
+			if club != nil {
+				p = club.NoShowPolicy
+			}
+			policies[b.ClubID] = p
+		}
+		// clubs without a policy may not use check-in at all
+		if p == nil {
+			continue
+		}
+		// a refund or cancellation may have got there first
+		marked, err := u.bookRepo.MarkNoShow(ctx, b.ID)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			continue
+		}
+		if err := u.banForNoShows(ctx, b, p, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// banForNoShows bans the user of b from its club once the no-shows within
+// the policy window reach the limit, unless a ban is already in force.
+func (u *banInteractor) banForNoShows(ctx context.Context, b *entities.Booking, p *entities.NoShowPolicy, now time.Time) error {
+	n, err := u.bookRepo.CountNoShows(ctx, b.UserID, b.ClubID, now.AddDate(0, 0, -p.WindowDays))
+	if err != nil || n < p.MaxNoShows {
+		return err
+	}
+	active, err := u.activeBan(ctx, b.UserID, b.ClubID)
+	if err != nil || active != nil {
+		return err
+	}
+	return u.repo.Create(ctx, &entities.Ban{
+		UserID:    b.UserID,
+		ClubID:    b.ClubID,
+		Reason:    fmt.Sprintf("%d no-shows in %d days", n, p.WindowDays),
+		Source:    entities.BanSourceNoShows,
+		BannedBy:  entities.BannedBySystem,
+		CreatedAt: now,
+		ExpiresAt: now.AddDate(0, 0, p.BanDays),
+	})
+}
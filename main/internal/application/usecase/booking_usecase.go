@@ -2,16 +2,59 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"main/internal/domain/entities"
 	"main/internal/domain/repository"
+	"main/internal/infrastructure/stripeclient"
+	"strings"
 	"time"
 )
 
+// checkInEarly is how long before its start a booking can be checked in.
+const checkInEarly = 15 * time.Minute
+
 type BookingUseCase interface {
 	GetByUser(ctx context.Context, userID string) ([]*entities.Booking, error)
+	GetByID(ctx context.Context, id string) (*entities.Booking, error)
+	Quote(ctx context.Context, req *entities.QuoteRequest) (*entities.Quote, error)
 	Create(ctx context.Context, b *entities.Booking) error
-	Cancel(ctx context.Context, id string) error
+	// Cancel cancels the user's own booking before it starts, refunding
+	// any payment. Bookings paid at the front desk are refunded there and
+	// fail with ErrRefundAtDesk.
+	Cancel(ctx context.Context, userID, id string) error
+	// CancelByClub cancels a booking on the club's behalf, e.g. when the
+	// club closes, whoever owns it and even once it has started. staffID
+	// is recorded against money handed back at the front desk.
+	CancelByClub(ctx context.Context, staffID, id string) error
+	PayWithWallet(ctx context.Context, userID, id string) (*entities.Booking, error)
+	// CreatePaymentIntent starts a card payment for the booking and returns
+	// the PaymentIntent client secret. An unpaid intent started earlier is
+	// handed out again and an open Checkout Session is expired, so the
+	// booking cannot be paid twice.
+	CreatePaymentIntent(ctx context.Context, userID, id string) (string, error)
+	// CreateCheckoutSession starts a hosted Checkout payment for the booking
+	// and returns the URL to redirect the user to. Like CreatePaymentIntent
+	// it reuses an open session and cancels an unpaid intent.
+	CreateCheckoutSession(ctx context.Context, userID, id, successURL, cancelURL string) (string, error)
+	// ExpireCheckout forgets an expired Checkout Session of an unpaid booking.
+	ExpireCheckout(ctx context.Context, id, sessionID string) error
+	// RecordOfflinePayment marks the booking as paid at the front desk by
+	// staffID with cash, a card terminal or a Kaspi transfer.
+	RecordOfflinePayment(ctx context.Context, staffID, id, method, reference string, amount float64) (*entities.Booking, error)
+	// ConfirmPayment marks the booking as paid and issues its invoice. A
+	// card payment the booking can no longer take, e.g. a second one or one
+	// for a cancelled booking, is refunded instead.
+	ConfirmPayment(ctx context.Context, id, method, reference string, amount float64) error
+	// CheckIn starts the session of a paid booking at the front desk and
+	// unlocks its computer.
+	CheckIn(ctx context.Context, id string) (*entities.Booking, error)
+	// Complete marks a paid booking as completed and credits loyalty points.
+	Complete(ctx context.Context, id string) (*entities.Booking, error)
+	// Refund reverses a paid or completed booking; staffID is recorded
+	// against money handed back at the front desk.
+	Refund(ctx context.Context, staffID, id string) (*entities.Booking, error)
 }
 
 // booking_usecase.go
@@ -19,58 +62,702 @@ type BookingUseCase interface {
 type bookingInteractor struct {
 	bookingRepo repository.BookingRepository
 	compRepo    repository.ComputerRepository
+	clubRepo    repository.ClubRepository
+	pricingUC   PricingUseCase
+	promoUC     PromoCodeUseCase
+	walletUC    WalletUseCase
+	memberUC    MembershipUseCase
+	loyaltyUC   LoyaltyUseCase
+	invoiceUC   InvoiceUseCase
+	ledgerUC    PaymentLedgerUseCase
+	commandUC   AgentCommandUseCase
+	userUC      UserUseCase
+	banUC       BanUseCase
 }
 
 func NewBookingUseCase(
 	bRepo repository.BookingRepository,
 	cRepo repository.ComputerRepository,
+	clubRepo repository.ClubRepository,
+	pricingUC PricingUseCase,
+	promoUC PromoCodeUseCase,
+	walletUC WalletUseCase,
+	memberUC MembershipUseCase,
+	loyaltyUC LoyaltyUseCase,
+	invoiceUC InvoiceUseCase,
+	ledgerUC PaymentLedgerUseCase,
+	commandUC AgentCommandUseCase,
+	userUC UserUseCase,
+	banUC BanUseCase,
 ) BookingUseCase {
-	return &bookingInteractor{bookingRepo: bRepo, compRepo: cRepo}
+	return &bookingInteractor{
+		bookingRepo: bRepo,
+		compRepo:    cRepo,
+		clubRepo:    clubRepo,
+		pricingUC:   pricingUC,
+		promoUC:     promoUC,
+		walletUC:    walletUC,
+		memberUC:    memberUC,
+		loyaltyUC:   loyaltyUC,
+		invoiceUC:   invoiceUC,
+		ledgerUC:    ledgerUC,
+		commandUC:   commandUC,
+		userUC:      userUC,
+		banUC:       banUC,
+	}
 }
 
 func (u *bookingInteractor) GetByUser(ctx context.Context, userID string) ([]*entities.Booking, error) {
-	return u.bookingRepo.FindAllByUser(ctx, userID)
+	list, err := u.bookingRepo.FindAllByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range list {
+		b.Localize()
+	}
+	return list, nil
+}
+
+func (u *bookingInteractor) GetByID(ctx context.Context, id string) (*entities.Booking, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.present(ctx, b)
+}
+
+// present prepares a booking for a response: times in the club's zone and
+// the user's name for staff screens.
+func (u *bookingInteractor) present(ctx context.Context, b *entities.Booking) (*entities.Booking, error) {
+	b.Localize()
+	names, err := u.userUC.Names(ctx, []string{b.UserID})
+	if err != nil {
+		return nil, err
+	}
+	b.UserName = names[b.UserID]
+	return b, nil
+}
+
+func (u *bookingInteractor) Quote(ctx context.Context, req *entities.QuoteRequest) (*entities.Quote, error) {
+	return u.pricingUC.Quote(ctx, req)
 }
 
 func (u *bookingInteractor) Create(ctx context.Context, b *entities.Booking) error {
-	b.Status = "active"
+	if err := u.banUC.Check(ctx, b.UserID, b.ClubID); err != nil {
+		return err
+	}
+	q, err := u.pricingUC.Quote(ctx, &entities.QuoteRequest{
+		UserID:       b.UserID,
+		ClubID:       b.ClubID,
+		PCNumber:     b.PCNumber,
+		StartTime:    b.StartTime,
+		EndTime:      b.EndTime,
+		PromoCode:    b.PromoCode,
+		RedeemPoints: b.PointsUsed,
+	})
+	if err != nil {
+		return err
+	}
+	if err := u.checkAge(ctx, b); err != nil {
+		return err
+	}
+	b.LineItems = q.LineItems
+	b.Subtotal = q.Subtotal
+	b.Discount = q.Discount
+	b.TaxRate = q.TaxRate
+	b.TaxInclusive = q.TaxInclusive
+	b.TaxAmount = q.TaxAmount
+	b.PromoCode = q.PromoCode
+	b.MembershipID = q.MembershipID
+	b.CoveredHours = q.CoveredHours
+	b.PointsUsed = q.PointsUsed
+	b.TotalPrice = q.Total
+	b.Status = entities.BookingActive
 	b.CreatedAt = time.Now()
+	b.Timezone = q.Timezone
+	b.Localize()
+
+	// consume promo uses, membership hours and points before persisting so
+	// each limit check happens atomically; undo them if a later step fails
+	var undo []func()
+	rollback := func() {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}
+	if q.PromoCodeID != "" {
+		redemptionID, err := u.promoUC.Redeem(ctx, q.PromoCodeID, b.UserID)
+		if err != nil {
+			return err
+		}
+		b.RedemptionID = redemptionID
+		undo = append(undo, func() { _ = u.promoUC.Release(ctx, redemptionID) })
+	}
+	if q.CoveredHours > 0 {
+		if err := u.memberUC.ConsumeHours(ctx, q.MembershipID, q.CoveredHours); err != nil {
+			rollback()
+			return err
+		}
+		undo = append(undo, func() { _ = u.memberUC.ReturnHours(ctx, q.MembershipID, q.CoveredHours, "") })
+	}
+	if q.PointsUsed > 0 {
+		burnID, err := u.loyaltyUC.Burn(ctx, b.UserID, b.ClubID, q.PointsUsed)
+		if err != nil {
+			rollback()
+			return err
+		}
+		undo = append(undo, func() {
+			_ = u.loyaltyUC.Restore(ctx, b.UserID, b.ClubID, q.PointsUsed, "restore_"+burnID)
+		})
+	}
 	if err := u.bookingRepo.Create(ctx, b); err != nil {
+		rollback()
 		return err
 	}
 	// now mark that computer as unavailable
+	return u.setAvailability(ctx, b, false)
+}
+
+// checkAge refuses a booking that overlaps the club's age-restricted hours
+// unless staff have verified the user is old enough. A user without a
+// profile counts as unverified.
+func (u *bookingInteractor) checkAge(ctx context.Context, b *entities.Booking) error {
+	club, err := u.clubRepo.FindByID(ctx, b.ClubID)
+	if err != nil {
+		return err
+	}
+	r := club.AgeRestriction
+	tz := club.TZ()
+	start, end := b.StartTime.In(tz), b.EndTime.In(tz)
+	if !r.Overlaps(start, end) {
+		return nil
+	}
+	user, err := u.userUC.Get(ctx, b.UserID)
+	if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+		return err
+	}
+	return r.Admit(user, start, end)
+}
+
+// setAvailability flips the booked computer's availability and the club's
+// free computer count with it.
+func (u *bookingInteractor) setAvailability(ctx context.Context, b *entities.Booking, available bool) error {
 	comps, err := u.compRepo.FindByClub(ctx, b.ClubID)
 	if err != nil {
 		return err
 	}
 	for _, comp := range comps {
-		if comp.PCNumber == b.PCNumber {
-			comp.IsAvailable = false
-			return u.compRepo.Update(ctx, comp)
+		if comp.PCNumber != b.PCNumber {
+			continue
 		}
+		// a computer in maintenance stays unavailable when a booking ends
+		if comp.IsAvailable == available || (available && comp.Maintenance != nil) {
+			return nil
+		}
+		comp.IsAvailable = available
+		if err := u.compRepo.Update(ctx, comp); err != nil {
+			return err
+		}
+		delta := -1
+		if available {
+			delta = 1
+		}
+		return u.clubRepo.AdjustAvailablePCs(ctx, b.ClubID, delta)
 	}
-	return fmt.Errorf("computer not found for update availability")
+	return fmt.Errorf("computer %d not found in club %s", b.PCNumber, b.ClubID)
 }
 
-func (u *bookingInteractor) Cancel(ctx context.Context, id string) error {
+func (u *bookingInteractor) Cancel(ctx context.Context, userID, id string) error {
 	b, err := u.bookingRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	b.Status = "cancelled"
+	if b.UserID != userID {
+		return entities.ErrBookingNotOwned
+	}
+	if !b.CheckedInAt.IsZero() || !time.Now().Before(b.StartTime) {
+		return entities.ErrBookingStarted
+	}
+	if b.Status == entities.BookingConfirmed && entities.IsOfflinePaymentMethod(b.PaymentMethod) {
+		return entities.ErrRefundAtDesk
+	}
+	return u.cancel(ctx, b, "")
+}
+
+func (u *bookingInteractor) CancelByClub(ctx context.Context, staffID, id string) error {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return u.cancel(ctx, b, staffID)
+}
+
+// cancel cancels an unpaid or paid booking, refunding the payment. The
+// refund and everything else given back is keyed by the booking and done
+// before the booking is saved, so a retry after a failure finishes the job
+// without giving anything back twice.
+func (u *bookingInteractor) cancel(ctx context.Context, b *entities.Booking, staffID string) error {
+	if b.Status != entities.BookingActive && b.Status != entities.BookingConfirmed {
+		return entities.ErrBookingTransition
+	}
+	if b.Status == entities.BookingConfirmed {
+		if err := u.refundPayment(ctx, b, staffID); err != nil {
+			return err
+		}
+	} else {
+		// best effort: a card payment that still arrives is refunded by
+		// ConfirmPayment
+		_ = u.closePayments(ctx, b)
+	}
+	if err := u.releaseBenefits(ctx, b, true); err != nil {
+		return err
+	}
+	u.endSession(ctx, b)
+	b.Status = entities.BookingCancelled
 	if err := u.bookingRepo.Update(ctx, b); err != nil {
 		return err
 	}
 	// restore availability
-	comps, err := u.compRepo.FindByClub(ctx, b.ClubID)
+	return u.setAvailability(ctx, b, true)
+}
+
+func (u *bookingInteractor) PayWithWallet(ctx context.Context, userID, id string) (*entities.Booking, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.UserID != userID {
+		return nil, entities.ErrBookingNotOwned
+	}
+	if b.Status != entities.BookingActive {
+		return nil, entities.ErrBookingNotPayable
+	}
+	// a card payment under way wins; an open one is called off so it
+	// cannot be paid as well
+	if err := u.closePayments(ctx, b); err != nil {
+		return nil, err
+	}
+	// the charge is keyed by booking ID, so retrying after a failed update
+	// does not debit the wallet twice
+	if err := u.walletUC.Charge(ctx, userID, b.TotalPrice, b.ID); err != nil {
+		return nil, err
+	}
+	reference := "charge_" + b.ID
+	err = u.markPaid(ctx, b, entities.PaymentMethodWallet, reference)
+	if errors.Is(err, entities.ErrBookingNotPayable) {
+		// another payment or a cancellation got there first
+		cur, ferr := u.bookingRepo.FindByID(ctx, id)
+		if ferr != nil {
+			return nil, ferr
+		}
+		if cur.PaymentRef == reference {
+			return u.present(ctx, cur)
+		}
+		if rerr := u.walletUC.Refund(ctx, userID, b.TotalPrice, b.ID); rerr != nil {
+			return nil, rerr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u.present(ctx, b)
+}
+
+func (u *bookingInteractor) CreatePaymentIntent(ctx context.Context, userID, id string) (string, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if b.UserID != userID {
+		return "", entities.ErrBookingNotOwned
+	}
+	if b.Status != entities.BookingActive || b.TotalPrice <= 0 {
+		return "", entities.ErrBookingNotPayable
+	}
+	if b.IntentID != "" {
+		pi, err := stripeclient.GetPaymentIntent(b.IntentID)
+		if err != nil {
+			return "", err
+		}
+		if stripeclient.IntentState(pi) == stripeclient.PaymentOpen {
+			return pi.ClientSecret, nil
+		}
+	}
+	if err := u.closePayments(ctx, b); err != nil {
+		return "", err
+	}
+	pi, err := stripeclient.CreatePaymentIntent(toMinorUnits(b.TotalPrice), DefaultCurrency, map[string]string{
+		PaymentPurposeKey: PaymentPurposeBooking,
+		PaymentBookingKey: b.ID,
+		PaymentUserKey:    userID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := u.ledgerUC.RecordIntent(ctx, pi.ID, pi.Amount, string(pi.Currency), pi.Metadata); err != nil {
+		return "", err
+	}
+	b.IntentID = pi.ID
+	if err := u.bookingRepo.Update(ctx, b); err != nil {
+		return "", err
+	}
+	return pi.ClientSecret, nil
+}
+
+func (u *bookingInteractor) CreateCheckoutSession(ctx context.Context, userID, id, successURL, cancelURL string) (string, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if b.UserID != userID {
+		return "", entities.ErrBookingNotOwned
+	}
+	if b.Status != entities.BookingActive || b.TotalPrice <= 0 {
+		return "", entities.ErrBookingNotPayable
+	}
+	if b.CheckoutID != "" {
+		s, err := stripeclient.GetCheckoutSession(b.CheckoutID)
+		if err != nil {
+			return "", err
+		}
+		if stripeclient.SessionState(s) == stripeclient.PaymentOpen {
+			return s.URL, nil
+		}
+	}
+	if err := u.closePayments(ctx, b); err != nil {
+		return "", err
+	}
+	metadata := map[string]string{
+		PaymentPurposeKey: PaymentPurposeBooking,
+		PaymentBookingKey: b.ID,
+		PaymentUserKey:    userID,
+	}
+	s, err := stripeclient.CreateCheckoutSession(b.ID, checkoutItems(b), DefaultCurrency,
+		strings.ReplaceAll(successURL, "{BOOKING_ID}", b.ID),
+		strings.ReplaceAll(cancelURL, "{BOOKING_ID}", b.ID),
+		metadata)
+	if err != nil {
+		return "", err
+	}
+	if s.PaymentIntent != nil {
+		if err := u.ledgerUC.RecordIntent(ctx, s.PaymentIntent.ID, s.AmountTotal, string(s.Currency), metadata); err != nil {
+			return "", err
+		}
+	}
+	b.CheckoutID = s.ID
+	if err := u.bookingRepo.Update(ctx, b); err != nil {
+		return "", err
+	}
+	return s.URL, nil
+}
+
+// closePayments cancels the booking's unpaid PaymentIntent and expires its
+// open Checkout Session, failing with ErrPaymentInProgress if either has
+// already taken money. It does not save the booking.
+func (u *bookingInteractor) closePayments(ctx context.Context, b *entities.Booking) error {
+	if b.IntentID != "" {
+		pi, err := stripeclient.GetPaymentIntent(b.IntentID)
+		if err != nil {
+			return err
+		}
+		switch stripeclient.IntentState(pi) {
+		case stripeclient.PaymentTaken:
+			return entities.ErrPaymentInProgress
+		case stripeclient.PaymentOpen:
+			if _, err := stripeclient.CancelPaymentIntent(pi.ID); err != nil {
+				return err
+			}
+		}
+		b.IntentID = ""
+	}
+	if b.CheckoutID != "" {
+		s, err := stripeclient.GetCheckoutSession(b.CheckoutID)
+		if err != nil {
+			return err
+		}
+		switch stripeclient.SessionState(s) {
+		case stripeclient.PaymentTaken:
+			return entities.ErrPaymentInProgress
+		case stripeclient.PaymentOpen:
+			if _, err := stripeclient.ExpireCheckoutSession(s.ID); err != nil {
+				return err
+			}
+		}
+		b.CheckoutID = ""
+	}
+	return nil
+}
+
+// checkoutItems lists the booking's quote lines for the Checkout page.
+// Checkout rejects negative amounts, so a booking with discounts is shown
+// as a single line for its total.
+func checkoutItems(b *entities.Booking) []stripeclient.CheckoutItem {
+	var items []stripeclient.CheckoutItem
+	var sum int64
+	for _, li := range b.LineItems {
+		amount := toMinorUnits(li.Amount)
+		if amount < 0 {
+			items = nil
+			break
+		}
+		if amount > 0 {
+			items = append(items, stripeclient.CheckoutItem{Name: li.Description, Amount: amount})
+			sum += amount
+		}
+	}
+	if items == nil || sum != toMinorUnits(b.TotalPrice) {
+		return []stripeclient.CheckoutItem{{
+			Name:   fmt.Sprintf("Booking PC %d, %s", b.PCNumber, b.StartTime.In(entities.LoadTimezone(b.Timezone)).Format("2006-01-02 15:04")),
+			Amount: toMinorUnits(b.TotalPrice),
+		}}
+	}
+	return items
+}
+
+func (u *bookingInteractor) ExpireCheckout(ctx context.Context, id, sessionID string) error {
+	b, err := u.bookingRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	for _, comp := range comps {
-		if comp.PCNumber == b.PCNumber {
-			comp.IsAvailable = true
-			return u.compRepo.Update(ctx, comp)
+	if b.Status != entities.BookingActive || b.CheckoutID != sessionID {
+		return nil
+	}
+	b.CheckoutID = ""
+	return u.bookingRepo.Update(ctx, b)
+}
+
+func (u *bookingInteractor) RecordOfflinePayment(ctx context.Context, staffID, id, method, reference string, amount float64) (*entities.Booking, error) {
+	if !entities.IsOfflinePaymentMethod(method) {
+		return nil, entities.ErrPaymentMethod
+	}
+	// transfers and terminal slips are matched by their reference later
+	if method != entities.PaymentMethodCash && reference == "" {
+		return nil, entities.ErrPaymentReference
+	}
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != entities.BookingActive {
+		return nil, entities.ErrBookingNotPayable
+	}
+	if toMinorUnits(amount) < toMinorUnits(b.TotalPrice) {
+		return nil, entities.ErrPaymentAmount
+	}
+	if reference == "" {
+		reference = "cash_" + b.ID
+	}
+	b.PaidBy = staffID
+	b.PaymentMethod = method
+	b.PaymentRef = reference
+	if err := u.ledgerUC.RecordOffline(ctx, entities.LedgerCharge, b, staffID); err != nil {
+		return nil, err
+	}
+	if err := u.markPaid(ctx, b, method, reference); err != nil {
+		return nil, err
+	}
+	return u.present(ctx, b)
+}
+
+func (u *bookingInteractor) ConfirmPayment(ctx context.Context, id, method, reference string, amount float64) error {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// webhook redeliveries find the booking already paid with this reference
+	if b.PaymentRef == reference {
+		if b.Status != entities.BookingConfirmed {
+			return nil
+		}
+		_, err := u.invoiceUC.Issue(ctx, b)
+		return err
+	}
+	payable := b.Status == entities.BookingActive && toMinorUnits(amount) >= toMinorUnits(b.TotalPrice)
+	if !payable && method == entities.PaymentMethodCard {
+		// the money has already been taken, so failing would only make the
+		// provider redeliver the event; the customer gets it back instead
+		return u.refundStray(ctx, reference, amount)
+	}
+	if b.Status != entities.BookingActive {
+		return entities.ErrBookingNotPayable
+	}
+	if toMinorUnits(amount) < toMinorUnits(b.TotalPrice) {
+		return entities.ErrPaymentAmount
+	}
+	err = u.markPaid(ctx, b, method, reference)
+	if !errors.Is(err, entities.ErrBookingNotPayable) || method != entities.PaymentMethodCard {
+		return err
+	}
+	// another payment or a cancellation got there first, unless this is a
+	// redelivery racing itself
+	cur, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil || cur.PaymentRef == reference {
+		return err
+	}
+	return u.refundStray(ctx, reference, amount)
+}
+
+// refundStray refunds a card payment the booking could not take. The
+// refund is keyed by the PaymentIntent, so redeliveries do not repeat it.
+func (u *bookingInteractor) refundStray(ctx context.Context, intentID string, amount float64) error {
+	re, err := stripeclient.RefundPaymentIntent(intentID, toMinorUnits(amount), "refund_"+intentID)
+	if err != nil {
+		return err
+	}
+	return u.ledgerUC.RecordRefund(ctx, re.ID, intentID, re.Amount, string(re.Currency))
+}
+
+// markPaid confirms the booking and issues its invoice. It fails with
+// ErrBookingNotPayable if the booking stopped being active since b was
+// read.
+func (u *bookingInteractor) markPaid(ctx context.Context, b *entities.Booking, method, reference string) error {
+	b.Status = entities.BookingConfirmed
+	b.PaymentMethod = method
+	b.PaymentRef = reference
+	b.PaidAt = time.Now()
+	paid, err := u.bookingRepo.MarkPaid(ctx, b)
+	if err != nil {
+		return err
+	}
+	if !paid {
+		return entities.ErrBookingNotPayable
+	}
+	_, err = u.invoiceUC.Issue(ctx, b)
+	return err
+}
+
+func (u *bookingInteractor) CheckIn(ctx context.Context, id string) (*entities.Booking, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	free := b.Status == entities.BookingActive && b.TotalPrice == 0
+	if (b.Status != entities.BookingConfirmed && !free) || !b.CheckedInAt.IsZero() {
+		return nil, entities.ErrBookingTransition
+	}
+	now := time.Now()
+	if now.Before(b.StartTime.Add(-checkInEarly)) || !now.Before(b.EndTime) {
+		return nil, entities.ErrCheckInWindow
+	}
+	// the verification may have been revoked since the booking was made
+	if err := u.checkAge(ctx, b); err != nil {
+		return nil, err
+	}
+	if err := u.banUC.Check(ctx, b.UserID, b.ClubID); err != nil {
+		return nil, err
+	}
+	b.CheckedInAt = now
+	if err := u.bookingRepo.Update(ctx, b); err != nil {
+		return nil, err
+	}
+	u.startSession(ctx, b)
+	return u.present(ctx, b)
+}
+
+func (u *bookingInteractor) Complete(ctx context.Context, id string) (*entities.Booking, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// a booking fully covered by a membership has nothing to pay
+	free := b.Status == entities.BookingActive && b.TotalPrice == 0
+	if b.Status != entities.BookingConfirmed && !free {
+		return nil, entities.ErrBookingTransition
+	}
+	// points are credited first and keyed by booking, so a retry after a
+	// failed save neither loses nor doubles them
+	points, err := u.loyaltyUC.Earn(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	b.PointsEarned = points
+	b.Status = entities.BookingCompleted
+	if err := u.bookingRepo.Update(ctx, b); err != nil {
+		return nil, err
+	}
+	u.endSession(ctx, b)
+	return u.present(ctx, b)
+}
+
+func (u *bookingInteractor) Refund(ctx context.Context, staffID, id string) (*entities.Booking, error) {
+	b, err := u.bookingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != entities.BookingConfirmed && b.Status != entities.BookingCompleted {
+		return nil, entities.ErrBookingTransition
+	}
+	// as in cancel, each step is keyed by the booking and the booking is
+	// saved last, so a failed refund can simply be retried
+	if err := u.refundPayment(ctx, b, staffID); err != nil {
+		return nil, err
+	}
+	// hours of a completed booking were actually played, so they stay used
+	if err := u.releaseBenefits(ctx, b, b.Status != entities.BookingCompleted); err != nil {
+		return nil, err
+	}
+	if err := u.loyaltyUC.Clawback(ctx, b); err != nil {
+		return nil, err
+	}
+	u.endSession(ctx, b)
+	b.Status = entities.BookingRefunded
+	if err := u.bookingRepo.Update(ctx, b); err != nil {
+		return nil, err
+	}
+	return u.present(ctx, b)
+}
+
+// startSession and endSession queue the PC commands of a booking. The
+// booking change stands without them and staff can send the commands by
+// hand, so a queue failure is logged rather than failing the request.
+func (u *bookingInteractor) startSession(ctx context.Context, b *entities.Booking) {
+	if err := u.commandUC.StartSession(ctx, b); err != nil {
+		log.Printf("booking %s: queue session start: %v", b.ID, err)
+	}
+}
+
+func (u *bookingInteractor) endSession(ctx context.Context, b *entities.Booking) {
+	if err := u.commandUC.EndSession(ctx, b); err != nil {
+		log.Printf("booking %s: queue session end: %v", b.ID, err)
+	}
+}
+
+// refundPayment returns the booking total through the method it was paid
+// with; staffID hands back money taken at the front desk.
+func (u *bookingInteractor) refundPayment(ctx context.Context, b *entities.Booking, staffID string) error {
+	switch b.PaymentMethod {
+	case entities.PaymentMethodWallet:
+		return u.walletUC.Refund(ctx, b.UserID, b.TotalPrice, b.ID)
+	case entities.PaymentMethodCard:
+		re, err := stripeclient.RefundPaymentIntent(b.PaymentRef, toMinorUnits(b.TotalPrice), "refund_"+b.ID)
+		if err != nil {
+			return err
+		}
+		return u.ledgerUC.RecordRefund(ctx, re.ID, b.PaymentRef, re.Amount, string(re.Currency))
+	case entities.PaymentMethodCash, entities.PaymentMethodTerminal, entities.PaymentMethodKaspi:
+		// the money is handed back at the front desk
+		return u.ledgerUC.RecordOffline(ctx, entities.LedgerRefund, b, staffID)
+	}
+	return nil
+}
+
+// releaseBenefits gives back the promo code use, the loyalty points and, if
+// returnHours is set, the membership hours a booking consumed.
+func (u *bookingInteractor) releaseBenefits(ctx context.Context, b *entities.Booking, returnHours bool) error {
+	if b.RedemptionID != "" {
+		if err := u.promoUC.Release(ctx, b.RedemptionID); err != nil {
+			return err
+		}
+	}
+	if returnHours && b.CoveredHours > 0 {
+		if err := u.memberUC.ReturnHours(ctx, b.MembershipID, b.CoveredHours, "return_"+b.ID); err != nil {
+			return err
+		}
+	}
+	if b.PointsUsed > 0 {
+		if err := u.loyaltyUC.Restore(ctx, b.UserID, b.ClubID, b.PointsUsed, "restore_"+b.ID); err != nil {
+			return err
 		}
 	}
-	return fmt.Errorf("computer not found for restore availability")
+	return nil
 }
@@ -2,44 +2,420 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"main/internal/domain/entities"
+	"main/internal/domain/geo"
 	"main/internal/domain/repository"
+	"time"
 )
 
+// MaxNearbyRadius caps nearby search so it cannot degrade into a full scan.
+const MaxNearbyRadius = 50000 // meters
+
+// Page sizes for paginated listings.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidLocation = errors.New("invalid coordinates")
+
 // ClubUseCase defines business logic for Club.
 type ClubUseCase interface {
 	GetAll(ctx context.Context) ([]*entities.Club, error)
 	GetByID(ctx context.Context, id string) (*entities.Club, error)
+	// Search returns a page of clubs matching f. With f.Near set it returns
+	// clubs within f.Radius meters, closest first unless sorted otherwise.
+	Search(ctx context.Context, f entities.ClubFilter) (*entities.ClubPage, error)
 	Create(ctx context.Context, c *entities.Club) error
+	// Update replaces the club's details; the owner is kept.
 	Update(ctx context.Context, c *entities.Club) error
-	Delete(ctx context.Context, id string) error
+	// SetOwner hands the club to the owner with UID ownerID.
+	SetOwner(ctx context.Context, id, ownerID string) (*entities.Club, error)
+	// SetHours replaces the club's schedule; nil removes it.
+	SetHours(ctx context.Context, id string, h *entities.OpeningHours) (*entities.Club, error)
+	// AddClosure closes the club for a period on top of its schedule.
+	AddClosure(ctx context.Context, id string, c entities.Closure) (*entities.Club, error)
+	// SetAgeRestriction replaces the club's age-restricted hours; nil
+	// removes them.
+	SetAgeRestriction(ctx context.Context, id string, r *entities.AgeRestriction) (*entities.Club, error)
+	// SetNoShowPolicy replaces the club's automatic no-show bans; nil turns
+	// them off.
+	SetNoShowPolicy(ctx context.Context, id string, p *entities.NoShowPolicy) (*entities.Club, error)
+	// Delete archives the club with its computers and revokes its API
+	// keys. Unpaid bookings that have not ended are cancelled; paid ones
+	// block deletion with ErrClubHasBookings unless cancelBookings is set,
+	// which cancels and refunds them too; by is recorded against money
+	// handed back at the front desk.
+	Delete(ctx context.Context, by, id string, cancelBookings bool) (*entities.ClubDeletion, error)
+	// Restore brings an archived club back with its computers. Its API
+	// keys stay revoked.
+	Restore(ctx context.Context, id string) (*entities.Club, error)
+	// Deleted lists archived clubs, most recently deleted first.
+	Deleted(ctx context.Context) ([]*entities.Club, error)
+	// PurgeDeleted removes clubs archived longer than the retention period
+	// for good, archiving their computers and dropping their floor plans.
+	// Bookings stay as financial records.
+	PurgeDeleted(ctx context.Context) error
 }
 
 type clubInteractor struct {
-	repo repository.ClubRepository
+	repo       repository.ClubRepository
+	compRepo   repository.ComputerRepository
+	bookRepo   repository.BookingRepository
+	floorRepo  repository.FloorPlanRepository
+	keyRepo    repository.APIKeyRepository
+	bookingUC  BookingUseCase
+	purgeAfter time.Duration
 }
 
-// NewClubUseCase constructs a new ClubUseCase with the given repository.
-func NewClubUseCase(r repository.ClubRepository) ClubUseCase {
-	return &clubInteractor{repo: r}
+// NewClubUseCase constructs a new ClubUseCase with the given repositories.
+// Deleted clubs are purged after purgeAfter; zero keeps them forever.
+func NewClubUseCase(
+	r repository.ClubRepository,
+	compRepo repository.ComputerRepository,
+	bookRepo repository.BookingRepository,
+	floorRepo repository.FloorPlanRepository,
+	keyRepo repository.APIKeyRepository,
+	bookingUC BookingUseCase,
+	purgeAfter time.Duration,
+) ClubUseCase {
+	return &clubInteractor{
+		repo:       r,
+		compRepo:   compRepo,
+		bookRepo:   bookRepo,
+		floorRepo:  floorRepo,
+		keyRepo:    keyRepo,
+		bookingUC:  bookingUC,
+		purgeAfter: purgeAfter,
+	}
 }
 
 func (i *clubInteractor) GetAll(ctx context.Context) ([]*entities.Club, error) {
-	return i.repo.FindAll(ctx)
+	list, err := i.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, c := range list {
+		c.Localize(now)
+	}
+	return list, nil
 }
 
 func (i *clubInteractor) GetByID(ctx context.Context, id string) (*entities.Club, error) {
-	return i.repo.FindByID(ctx, id)
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+func (i *clubInteractor) Search(ctx context.Context, f entities.ClubFilter) (*entities.ClubPage, error) {
+	f.Limit = pageSize(f.Limit)
+	page, err := i.search(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, c := range page.Items {
+		c.Localize(now)
+	}
+	return page, nil
+}
+
+func (i *clubInteractor) search(ctx context.Context, f entities.ClubFilter) (*entities.ClubPage, error) {
+	if f.Near != nil {
+		return i.searchNear(ctx, f)
+	}
+	if f.SortBy == entities.ClubSortDistance {
+		return nil, ErrInvalidLocation
+	}
+	list, next, err := i.repo.Search(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	out := &entities.ClubPage{Items: make([]*entities.ClubDistance, 0, len(list)), NextCursor: next}
+	for _, c := range list {
+		out.Items = append(out.Items, &entities.ClubDistance{Club: c})
+	}
+	return out, nil
+}
+
+func (i *clubInteractor) searchNear(ctx context.Context, f entities.ClubFilter) (*entities.ClubPage, error) {
+	if !f.Near.Valid() || f.Radius <= 0 || f.Radius > MaxNearbyRadius {
+		return nil, ErrInvalidLocation
+	}
+	items, next, err := i.repo.SearchNear(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ClubPage{Items: items, NextCursor: next}, nil
+}
+
+// pageSize clamps a requested page size.
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(limit, MaxPageSize)
 }
 
 func (i *clubInteractor) Create(ctx context.Context, c *entities.Club) error {
+	if err := prepareClub(c); err != nil {
+		return err
+	}
 	return i.repo.Create(ctx, c)
 }
 
 func (i *clubInteractor) Update(ctx context.Context, c *entities.Club) error {
+	if err := prepareClub(c); err != nil {
+		return err
+	}
 	return i.repo.Update(ctx, c)
 }
 
-func (i *clubInteractor) Delete(ctx context.Context, id string) error {
-	return i.repo.Delete(ctx, id)
+func (i *clubInteractor) SetOwner(ctx context.Context, id, ownerID string) (*entities.Club, error) {
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.OwnerID = ownerID
+	if err := i.repo.Update(ctx, c, "owner_id"); err != nil {
+		return nil, err
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+func (i *clubInteractor) SetHours(ctx context.Context, id string, h *entities.OpeningHours) (*entities.Club, error) {
+	if h != nil {
+		if err := h.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Hours = h
+	if err := i.repo.Update(ctx, c, "hours"); err != nil {
+		return nil, err
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+func (i *clubInteractor) SetAgeRestriction(ctx context.Context, id string, r *entities.AgeRestriction) (*entities.Club, error) {
+	if r != nil {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.AgeRestriction = r
+	if err := i.repo.Update(ctx, c, "age_restriction"); err != nil {
+		return nil, err
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+func (i *clubInteractor) SetNoShowPolicy(ctx context.Context, id string, p *entities.NoShowPolicy) (*entities.Club, error) {
+	if p != nil {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.NoShowPolicy = p
+	if err := i.repo.Update(ctx, c, "no_show_policy"); err != nil {
+		return nil, err
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+func (i *clubInteractor) AddClosure(ctx context.Context, id string, cl entities.Closure) (*entities.Club, error) {
+	if !cl.To.After(cl.From) {
+		return nil, entities.ErrInvalidHours
+	}
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Hours == nil {
+		// a club without a schedule is open around the clock
+		c.Hours = &entities.OpeningHours{AlwaysOpen: true}
+	}
+	c.Hours.Closures = append(c.Hours.Closures, cl)
+	if err := i.repo.Update(ctx, c, "hours"); err != nil {
+		return nil, err
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+// prepareClub validates the schedule, timezone and tax rate and derives indexed fields.
+func prepareClub(c *entities.Club) error {
+	if c.Timezone == "" {
+		c.Timezone = entities.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return entities.ErrInvalidTimezone
+	}
+	if err := c.Tax.Validate(); err != nil {
+		return err
+	}
+	if c.Hours != nil {
+		if err := c.Hours.Validate(); err != nil {
+			return err
+		}
+	}
+	for category := range c.CategoryPrices {
+		if !entities.IsValidCategory(category) {
+			return entities.ErrInvalidCategory
+		}
+	}
+	return setGeohash(c)
+}
+
+// setGeohash derives the indexed geohash from the club's coordinates.
+func setGeohash(c *entities.Club) error {
+	if c.Location == nil {
+		return nil
+	}
+	p := c.Location.Point()
+	if !p.Valid() {
+		return ErrInvalidLocation
+	}
+	c.Location.Geohash = geo.Encode(p, geo.MaxPrecision)
+	return nil
+}
+
+func (i *clubInteractor) Delete(ctx context.Context, by, id string, cancelBookings bool) (*entities.ClubDeletion, error) {
+	c, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	open, err := i.bookRepo.FindOpenByClub(ctx, id, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range open {
+		if b.Status == entities.BookingConfirmed && !cancelBookings {
+			return nil, entities.ErrClubHasBookings
+		}
+	}
+	for _, b := range open {
+		if err := i.bookingUC.CancelByClub(ctx, by, b.ID); err != nil {
+			return nil, err
+		}
+		b.Status = entities.BookingCancelled
+	}
+	keys, err := i.keyRepo.FindByClub(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if !k.RevokedAt.IsZero() {
+			continue
+		}
+		k.RevokedAt = now
+		if err := i.keyRepo.Update(ctx, k); err != nil {
+			return nil, err
+		}
+	}
+	comps, err := i.compRepo.FindByClub(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, comp := range comps {
+		comp.DeletedAt = now
+		comp.WithClub = true
+		if err := i.compRepo.Delete(ctx, comp); err != nil {
+			return nil, err
+		}
+	}
+	// cancelling gave the club its computers back; archive it as it is now
+	if c, err = i.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	c.DeletedAt = now
+	if err := i.repo.Delete(ctx, c); err != nil {
+		return nil, err
+	}
+	if open == nil {
+		open = make([]*entities.Booking, 0)
+	}
+	return &entities.ClubDeletion{Club: c, Cancelled: open}, nil
+}
+
+func (i *clubInteractor) Restore(ctx context.Context, id string) (*entities.Club, error) {
+	c, err := i.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	comps, err := i.compRepo.FindArchivedWithClub(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, comp := range comps {
+		comp.DeletedAt = time.Time{}
+		comp.WithClub = false
+		if err := i.compRepo.Restore(ctx, comp); err != nil {
+			return nil, err
+		}
+	}
+	c.Localize(time.Now())
+	return c, nil
+}
+
+func (i *clubInteractor) Deleted(ctx context.Context) ([]*entities.Club, error) {
+	list, err := i.repo.FindDeletedBefore(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = make([]*entities.Club, 0)
+	}
+	return list, nil
+}
+
+func (i *clubInteractor) PurgeDeleted(ctx context.Context) error {
+	if i.purgeAfter <= 0 {
+		return nil
+	}
+	now := time.Now()
+	list, err := i.repo.FindDeletedBefore(ctx, now.Add(-i.purgeAfter))
+	if err != nil {
+		return err
+	}
+	for _, c := range list {
+		// Delete archived the club's computers along with it
+		comps, err := i.compRepo.FindArchivedWithClub(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		for _, comp := range comps {
+			if err := i.compRepo.Purge(ctx, comp); err != nil {
+				return err
+			}
+		}
+		if err := i.floorRepo.Delete(ctx, c.ID); err != nil {
+			return err
+		}
+		if err := i.repo.Purge(ctx, c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -2,34 +2,463 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"main/internal/domain/entities"
 	"main/internal/domain/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxBookingSpan bounds how long before a window a booking that still
+	// overlaps it can have started.
+	maxBookingSpan = entities.MaxBookingDuration
+	// displacementHorizon is how far ahead bookings are moved off a computer
+	// removed with no return date.
+	displacementHorizon = 90 * 24 * time.Hour
 )
 
 // ComputerUseCase defines business logic for Computer.
 type ComputerUseCase interface {
 	GetAll(ctx context.Context) ([]*entities.Computer, error)
+	Search(ctx context.Context, f entities.ComputerFilter) (*entities.ComputerPage, error)
+	// SearchAll returns every computer matching f; its cursor and limit
+	// are ignored.
+	SearchAll(ctx context.Context, f entities.ComputerFilter) ([]*entities.Computer, error)
 	GetByClub(ctx context.Context, clubID string) ([]*entities.Computer, error)
+	GetByID(ctx context.Context, id string) (*entities.Computer, error)
 	Create(ctx context.Context, comp *entities.Computer) error
+	// Import validates rows for clubID and, unless dryRun, creates them all
+	// or none. Invalid rows yield the report and ErrImportInvalid.
+	Import(ctx context.Context, clubID string, rows []entities.ImportRow, dryRun bool) (*entities.ImportReport, error)
+	// Update edits a computer's number, description, category and specs.
+	// Availability and maintenance have their own operations. The number
+	// cannot change while bookings hold the computer (ErrComputerBooked).
+	Update(ctx context.Context, comp *entities.Computer) (*entities.Computer, error)
+	// Delete soft-deletes a computer and moves its future bookings off it.
+	Delete(ctx context.Context, id string) (*entities.DisplacementReport, error)
+	// StartMaintenance takes a computer out of service until expectedBack
+	// (zero if unknown) and moves conflicting bookings off it.
+	StartMaintenance(ctx context.Context, staffID, id, reason string, expectedBack time.Time) (*entities.DisplacementReport, error)
+	EndMaintenance(ctx context.Context, id string) (*entities.Computer, error)
 }
 
 type computerInteractor struct {
-	repo repository.ComputerRepository
+	repo        repository.ComputerRepository
+	clubRepo    repository.ClubRepository
+	bookingRepo repository.BookingRepository
 }
 
-// NewComputerUseCase constructs a new ComputerUseCase with the given repository.
-func NewComputerUseCase(r repository.ComputerRepository) ComputerUseCase {
-	return &computerInteractor{repo: r}
+// NewComputerUseCase constructs a new ComputerUseCase with the given repositories.
+func NewComputerUseCase(
+	r repository.ComputerRepository,
+	clubRepo repository.ClubRepository,
+	bookingRepo repository.BookingRepository,
+) ComputerUseCase {
+	return &computerInteractor{repo: r, clubRepo: clubRepo, bookingRepo: bookingRepo}
 }
 
 func (u *computerInteractor) GetAll(ctx context.Context) ([]*entities.Computer, error) {
 	return u.repo.FindAll(ctx)
 }
 
+func (u *computerInteractor) Search(ctx context.Context, f entities.ComputerFilter) (*entities.ComputerPage, error) {
+	f.Limit = pageSize(f.Limit)
+	f.GPU = entities.HardwareKey(f.GPU)
+	list, next, err := u.repo.Search(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = make([]*entities.Computer, 0)
+	}
+	return &entities.ComputerPage{Items: list, NextCursor: next}, nil
+}
+
+func (u *computerInteractor) SearchAll(ctx context.Context, f entities.ComputerFilter) ([]*entities.Computer, error) {
+	f.Cursor, f.Limit = "", MaxPageSize
+	out := make([]*entities.Computer, 0)
+	for {
+		page, err := u.Search(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page.Items...)
+		if page.NextCursor == "" {
+			return out, nil
+		}
+		f.Cursor = page.NextCursor
+	}
+}
+
 func (u *computerInteractor) GetByClub(ctx context.Context, clubID string) ([]*entities.Computer, error) {
 	return u.repo.FindByClub(ctx, clubID)
 }
 
+func (u *computerInteractor) GetByID(ctx context.Context, id string) (*entities.Computer, error) {
+	return u.repo.FindByID(ctx, id)
+}
+
+// Create adds a computer and keeps the club's search counters in step.
 func (u *computerInteractor) Create(ctx context.Context, comp *entities.Computer) error {
-	return u.repo.Create(ctx, comp)
+	if err := normalizeComputer(comp); err != nil {
+		return err
+	}
+	if errs := validateComputer(comp); len(errs) > 0 {
+		return errs[0]
+	}
+	if err := u.checkNumber(ctx, comp); err != nil {
+		return err
+	}
+	comp.Maintenance = nil
+	comp.Agent = nil
+	if err := u.repo.Create(ctx, comp); err != nil {
+		return err
+	}
+	return u.addToClub(ctx, comp.ClubID, []*entities.Computer{comp})
+}
+
+func (u *computerInteractor) Import(ctx context.Context, clubID string, rows []entities.ImportRow, dryRun bool) (*entities.ImportReport, error) {
+	if len(rows) > entities.MaxImportRows {
+		return nil, fmt.Errorf("%w: at most %d", entities.ErrImportTooLarge, entities.MaxImportRows)
+	}
+	if _, err := u.clubRepo.FindByID(ctx, clubID); err != nil {
+		return nil, ErrInvalidClub
+	}
+	existing, err := u.repo.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[int]bool, len(existing))
+	for _, c := range existing {
+		taken[c.PCNumber] = true
+	}
+
+	rep := &entities.ImportReport{DryRun: dryRun, Total: len(rows), Rows: make([]entities.ImportRowResult, 0, len(rows))}
+	seen := make(map[int]int)
+	comps := make([]*entities.Computer, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		comp := &row.Computer
+		comp.ClubID = clubID
+		comp.Maintenance = nil
+		comp.Agent = nil
+		res := entities.ImportRowResult{Row: row.Row, PCNumber: comp.PCNumber, Errors: row.Errors}
+		if len(row.Errors) == 0 {
+			if err := normalizeComputer(comp); err != nil {
+				res.Errors = append(res.Errors, err.Error())
+			}
+			for _, err := range validateComputer(comp) {
+				res.Errors = append(res.Errors, err.Error())
+			}
+			if first, ok := seen[comp.PCNumber]; ok && comp.PCNumber > 0 {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: also in row %d", entities.ErrDuplicatePCNumber, first))
+			} else if taken[comp.PCNumber] {
+				res.Errors = append(res.Errors, entities.ErrDuplicatePCNumber.Error())
+			} else {
+				seen[comp.PCNumber] = row.Row
+			}
+		}
+		if len(res.Errors) == 0 {
+			rep.Valid++
+		}
+		rep.Rows = append(rep.Rows, res)
+		comps = append(comps, comp)
+	}
+	if !rep.OK() {
+		return rep, entities.ErrImportInvalid
+	}
+	if dryRun || len(comps) == 0 {
+		return rep, nil
+	}
+	if err := u.repo.CreateBatch(ctx, comps); err != nil {
+		return nil, err
+	}
+	if err := u.addToClub(ctx, clubID, comps); err != nil {
+		return nil, err
+	}
+	rep.Computers = comps
+	return rep, nil
+}
+
+// checkNumber rejects a PC number used by another computer of the club. The
+// repository guards the same rule against concurrent writes but only knows
+// computers written since numbers were indexed.
+func (u *computerInteractor) checkNumber(ctx context.Context, comp *entities.Computer) error {
+	comps, err := u.repo.FindByClub(ctx, comp.ClubID)
+	if err != nil {
+		return err
+	}
+	for _, c := range comps {
+		if c.ID != comp.ID && c.PCNumber == comp.PCNumber {
+			return fmt.Errorf("%w: %d", entities.ErrDuplicatePCNumber, comp.PCNumber)
+		}
+	}
+	return nil
+}
+
+// addToClub updates the club's search counters for new computers.
+func (u *computerInteractor) addToClub(ctx context.Context, clubID string, comps []*entities.Computer) error {
+	available, gpuClass := 0, 0
+	for _, c := range comps {
+		if c.IsAvailable {
+			available++
+		}
+		if c.GPUClass > gpuClass {
+			gpuClass = c.GPUClass
+		}
+	}
+	if available > 0 {
+		if err := u.clubRepo.AdjustAvailablePCs(ctx, clubID, available); err != nil {
+			return err
+		}
+	}
+	if gpuClass > 0 {
+		return u.clubRepo.RaiseGPUClass(ctx, clubID, gpuClass)
+	}
+	return nil
+}
+
+// syncGPUClass recomputes the club's best GPU class after a computer was
+// removed or downgraded.
+func (u *computerInteractor) syncGPUClass(ctx context.Context, clubID string) error {
+	comps, err := u.repo.FindByClub(ctx, clubID)
+	if err != nil {
+		return err
+	}
+	best := 0
+	for _, c := range comps {
+		best = max(best, c.GPUClass)
+	}
+	return u.clubRepo.SetGPUClass(ctx, clubID, best)
+}
+
+func (u *computerInteractor) Update(ctx context.Context, comp *entities.Computer) (*entities.Computer, error) {
+	existing, err := u.repo.FindByID(ctx, comp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeComputer(comp); err != nil {
+		return nil, err
+	}
+	if errs := validateComputer(comp); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	comp.ClubID = existing.ClubID
+	if err := u.checkNumber(ctx, comp); err != nil {
+		return nil, err
+	}
+	if comp.PCNumber != existing.PCNumber {
+		// bookings hold a computer by its number
+		open, err := u.bookingRepo.FindOpenByClub(ctx, existing.ClubID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range open {
+			if b.PCNumber == existing.PCNumber {
+				return nil, entities.ErrComputerBooked
+			}
+		}
+	}
+	downgraded := comp.GPUClass < existing.GPUClass
+	existing.PCNumber = comp.PCNumber
+	existing.Description = comp.Description
+	existing.Category = comp.Category
+	existing.Specs = comp.Specs
+	existing.GPUClass = comp.GPUClass
+	if err := u.repo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	if downgraded {
+		if err := u.syncGPUClass(ctx, existing.ClubID); err != nil {
+			return nil, err
+		}
+	} else if existing.GPUClass > 0 {
+		if err := u.clubRepo.RaiseGPUClass(ctx, existing.ClubID, existing.GPUClass); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+func (u *computerInteractor) Delete(ctx context.Context, id string) (*entities.DisplacementReport, error) {
+	comp, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rep, err := u.displace(ctx, comp, time.Time{}, "computer removed")
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setAvailable(ctx, comp, false); err != nil {
+		return nil, err
+	}
+	comp.DeletedAt = time.Now()
+	if err := u.repo.Delete(ctx, comp); err != nil {
+		return nil, err
+	}
+	if comp.GPUClass > 0 {
+		if err := u.syncGPUClass(ctx, comp.ClubID); err != nil {
+			return nil, err
+		}
+	}
+	return rep, nil
+}
+
+func (u *computerInteractor) StartMaintenance(ctx context.Context, staffID, id, reason string, expectedBack time.Time) (*entities.DisplacementReport, error) {
+	now := time.Now()
+	if reason == "" || (!expectedBack.IsZero() && !expectedBack.After(now)) {
+		return nil, entities.ErrInvalidMaintenance
+	}
+	comp, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	comp.Maintenance = &entities.Maintenance{
+		Reason:       reason,
+		Since:        now,
+		ExpectedBack: expectedBack,
+		StaffID:      staffID,
+	}
+	if err := u.setAvailable(ctx, comp, false); err != nil {
+		return nil, err
+	}
+	return u.displace(ctx, comp, expectedBack, "computer in maintenance: "+reason)
+}
+
+func (u *computerInteractor) EndMaintenance(ctx context.Context, id string) (*entities.Computer, error) {
+	comp, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comp.Maintenance == nil {
+		return nil, entities.ErrNotInMaintenance
+	}
+	comp.Maintenance = nil
+	if err := u.setAvailable(ctx, comp, true); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
+
+// displace moves bookings holding comp between now and until (zero for no
+// end) to a free computer of the same category, and flags the ones that
+// cannot be moved, including sessions already in progress.
+func (u *computerInteractor) displace(ctx context.Context, comp *entities.Computer, until time.Time, flag string) (*entities.DisplacementReport, error) {
+	now := time.Now()
+	queryTo := until
+	if queryTo.IsZero() {
+		queryTo = now.Add(displacementHorizon)
+	}
+	bookings, err := u.bookingRepo.FindByClub(ctx, comp.ClubID, now.Add(-maxBookingSpan), queryTo)
+	if err != nil {
+		return nil, err
+	}
+	comps, err := u.repo.FindByClub(ctx, comp.ClubID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(comps, func(i, j int) bool { return comps[i].PCNumber < comps[j].PCNumber })
+	busy := func(pc int, b *entities.Booking) bool {
+		for _, o := range bookings {
+			if o != b && o.Holds() && o.PCNumber == pc && o.Overlaps(b.StartTime, b.EndTime) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var conflicts []*entities.Booking
+	for _, b := range bookings {
+		if b.Holds() && b.PCNumber == comp.PCNumber && b.Overlaps(now, until) {
+			conflicts = append(conflicts, b)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].StartTime.Before(conflicts[j].StartTime) })
+
+	rep := &entities.DisplacementReport{
+		Computer:  comp,
+		Relocated: []*entities.Booking{},
+		Flagged:   []*entities.Booking{},
+	}
+	for _, b := range conflicts {
+		var target *entities.Computer
+		if b.StartTime.After(now) {
+			for _, c := range comps {
+				if c.ID != comp.ID && c.Category == comp.Category && c.Maintenance == nil && !busy(c.PCNumber, b) {
+					target = c
+					break
+				}
+			}
+		}
+		if target == nil {
+			b.Flag = flag
+			if err := u.bookingRepo.Update(ctx, b); err != nil {
+				return nil, err
+			}
+			rep.Flagged = append(rep.Flagged, b)
+			continue
+		}
+		b.RelocatedFrom = b.PCNumber
+		b.PCNumber = target.PCNumber
+		b.Flag = ""
+		if err := u.bookingRepo.Update(ctx, b); err != nil {
+			return nil, err
+		}
+		// the moved booking now holds the target like a new one would
+		if err := u.setAvailable(ctx, target, false); err != nil {
+			return nil, err
+		}
+		rep.Relocated = append(rep.Relocated, b)
+	}
+	return rep, nil
+}
+
+// setAvailable saves comp with the given availability and keeps the club's
+// free computer count in step.
+func (u *computerInteractor) setAvailable(ctx context.Context, comp *entities.Computer, available bool) error {
+	changed := comp.IsAvailable != available
+	comp.IsAvailable = available
+	if err := u.repo.Update(ctx, comp); err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	delta := -1
+	if available {
+		delta = 1
+	}
+	return u.clubRepo.AdjustAvailablePCs(ctx, comp.ClubID, delta)
+}
+
+// normalizeComputer defaults and validates the category and derives the
+// indexed spec fields.
+func normalizeComputer(comp *entities.Computer) error {
+	if comp.Category == "" {
+		comp.Category = entities.CategoryStandard
+	}
+	if !entities.IsValidCategory(comp.Category) {
+		return fmt.Errorf("%w: %s", entities.ErrInvalidCategory, comp.Category)
+	}
+	comp.Specs.GPUKey = entities.HardwareKey(comp.Specs.GPU)
+	return nil
+}
+
+// validateComputer returns every problem with the number and specs of comp.
+// Consoles have no PC hardware to describe.
+func validateComputer(comp *entities.Computer) []error {
+	var errs []error
+	if comp.PCNumber <= 0 {
+		errs = append(errs, entities.ErrInvalidPCNumber)
+	}
+	if comp.Category == entities.CategoryConsole {
+		return errs
+	}
+	s := comp.Specs
+	if strings.TrimSpace(s.GPU) == "" || strings.TrimSpace(s.CPU) == "" || s.RAMGB <= 0 || s.MonitorHz <= 0 {
+		errs = append(errs, entities.ErrMissingSpecs)
+	}
+	return errs
 }
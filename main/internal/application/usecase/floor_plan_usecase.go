@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+// FloorPlanUseCase defines business logic for club floor plans.
+type FloorPlanUseCase interface {
+	Get(ctx context.Context, clubID string) (*entities.FloorPlan, error)
+	// Save replaces the club's plan; every seat must be a computer of the club.
+	Save(ctx context.Context, plan *entities.FloorPlan) (*entities.FloorPlan, error)
+	Delete(ctx context.Context, clubID string) error
+	// SeatMap returns the plan with each seat's status over [from, to).
+	// Seats whose computer was removed are left out.
+	SeatMap(ctx context.Context, clubID string, from, to time.Time) (*entities.SeatMap, error)
+}
+
+type floorPlanInteractor struct {
+	repo        repository.FloorPlanRepository
+	clubRepo    repository.ClubRepository
+	compRepo    repository.ComputerRepository
+	bookingRepo repository.BookingRepository
+}
+
+// NewFloorPlanUseCase constructs a new FloorPlanUseCase with the given repositories.
+func NewFloorPlanUseCase(
+	r repository.FloorPlanRepository,
+	clubRepo repository.ClubRepository,
+	compRepo repository.ComputerRepository,
+	bookingRepo repository.BookingRepository,
+) FloorPlanUseCase {
+	return &floorPlanInteractor{repo: r, clubRepo: clubRepo, compRepo: compRepo, bookingRepo: bookingRepo}
+}
+
+func (u *floorPlanInteractor) Get(ctx context.Context, clubID string) (*entities.FloorPlan, error) {
+	return u.repo.FindByClub(ctx, clubID)
+}
+
+func (u *floorPlanInteractor) Save(ctx context.Context, plan *entities.FloorPlan) (*entities.FloorPlan, error) {
+	if _, err := u.clubRepo.FindByID(ctx, plan.ClubID); err != nil {
+		return nil, ErrInvalidClub
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	comps, err := u.computersByID(ctx, plan.ClubID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range plan.ComputerIDs() {
+		if comps[id] == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownComputer, id)
+		}
+	}
+	plan.UpdatedAt = time.Now()
+	if err := u.repo.Save(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (u *floorPlanInteractor) Delete(ctx context.Context, clubID string) error {
+	if _, err := u.repo.FindByClub(ctx, clubID); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, clubID)
+}
+
+func (u *floorPlanInteractor) SeatMap(ctx context.Context, clubID string, from, to time.Time) (*entities.SeatMap, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidInterval
+	}
+	club, err := u.clubRepo.FindByID(ctx, clubID)
+	if err != nil {
+		return nil, ErrInvalidClub
+	}
+	plan, err := u.repo.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	comps, err := u.computersByID(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := u.bookingRepo.FindByClub(ctx, clubID, from.Add(-maxBookingSpan), to)
+	if err != nil {
+		return nil, err
+	}
+	booked := make(map[int]bool)
+	for _, b := range bookings {
+		if b.Holds() && b.Overlaps(from, to) {
+			booked[b.PCNumber] = true
+		}
+	}
+	loc := club.TZ()
+	closed := club.Hours.Check(from.In(loc), to.In(loc)) != nil
+	// agent status only says something about the present
+	live := !from.After(time.Now().Add(entities.AgentOfflineAfter))
+
+	m := &entities.SeatMap{
+		ClubID:   clubID,
+		From:     from.In(loc),
+		To:       to.In(loc),
+		Timezone: loc.String(),
+		Rooms:    make([]entities.SeatMapRoom, 0, len(plan.Rooms)),
+	}
+	for _, r := range plan.Rooms {
+		room := entities.SeatMapRoom{Room: r, Seats: make([]entities.MapSeat, 0, len(r.Seats))}
+		for _, s := range r.Seats {
+			comp := comps[s.ComputerID]
+			if comp == nil {
+				continue
+			}
+			seat := entities.MapSeat{
+				Seat:     s,
+				PCNumber: comp.PCNumber,
+				Category: comp.Category,
+				Specs:    comp.Specs,
+				Status:   entities.SeatFree,
+			}
+			switch {
+			case closed:
+				seat.Status = entities.SeatUnavailable
+			case comp.InMaintenanceDuring(from, to):
+				seat.Status = entities.SeatMaintenance
+			case live && comp.Agent != nil && !comp.Agent.Online:
+				seat.Status = entities.SeatOffline
+			case booked[comp.PCNumber]:
+				seat.Status = entities.SeatBooked
+			}
+			room.Seats = append(room.Seats, seat)
+		}
+		m.Rooms = append(m.Rooms, room)
+	}
+	return m, nil
+}
+
+func (u *floorPlanInteractor) computersByID(ctx context.Context, clubID string) (map[string]*entities.Computer, error) {
+	list, err := u.compRepo.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	comps := make(map[string]*entities.Computer, len(list))
+	for _, c := range list {
+		comps[c.ID] = c
+	}
+	return comps, nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"main/internal/infrastructure/pdf"
+	"time"
+)
+
+// InvoiceUseCase defines business logic for booking receipts.
+type InvoiceUseCase interface {
+	// Issue creates the invoice for a paid booking. Issuing twice returns
+	// the existing invoice.
+	Issue(ctx context.Context, b *entities.Booking) (*entities.Invoice, error)
+	// GetByBooking returns the booking's invoice to its customer, admins,
+	// the club's owner and staff and front-desk keys of the club. Others
+	// get ErrInvoiceForbidden.
+	GetByBooking(ctx context.Context, by *entities.Principal, bookingID string) (*entities.Invoice, error)
+	RenderPDF(inv *entities.Invoice) []byte
+}
+
+type invoiceInteractor struct {
+	repo     repository.InvoiceRepository
+	clubRepo repository.ClubRepository
+}
+
+// NewInvoiceUseCase constructs a new InvoiceUseCase with the given repositories.
+func NewInvoiceUseCase(r repository.InvoiceRepository, cRepo repository.ClubRepository) InvoiceUseCase {
+	return &invoiceInteractor{repo: r, clubRepo: cRepo}
+}
+
+func (u *invoiceInteractor) Issue(ctx context.Context, b *entities.Booking) (*entities.Invoice, error) {
+	club, err := u.clubRepo.FindByID(ctx, b.ClubID)
+	if err != nil {
+		return nil, err
+	}
+	inv := &entities.Invoice{
+		BookingID:        b.ID,
+		UserID:           b.UserID,
+		ClubID:           b.ClubID,
+		ClubName:         club.Name,
+		Seller:           club.Legal,
+		LineItems:        b.LineItems,
+		Subtotal:         b.Subtotal,
+		Discount:         b.Discount,
+		TaxRate:          b.TaxRate,
+		TaxInclusive:     b.TaxInclusive,
+		TaxAmount:        b.TaxAmount,
+		TaxID:            club.Tax.TaxID,
+		Total:            b.TotalPrice,
+		Currency:         DefaultCurrency,
+		PaymentMethod:    b.PaymentMethod,
+		PaymentReference: b.PaymentRef,
+		IssuedAt:         time.Now().In(club.TZ()),
+		Timezone:         club.TZ().String(),
+	}
+	if inv.Seller.Name == "" {
+		inv.Seller.Name = club.Name
+	}
+	if inv.Seller.Address == "" {
+		inv.Seller.Address = club.Address
+	}
+	if err := u.repo.CreateForBooking(ctx, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (u *invoiceInteractor) GetByBooking(ctx context.Context, by *entities.Principal, bookingID string) (*entities.Invoice, error) {
+	inv, err := u.repo.FindByBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case by.Kind == entities.PrincipalUser && inv.UserID == by.UID,
+		by.Role == entities.RoleAdmin:
+		return inv, nil
+	case by.Role == entities.RoleOwner:
+		club, err := u.clubRepo.FindByID(ctx, inv.ClubID)
+		if err != nil {
+			return nil, err
+		}
+		if club.OwnerID == by.UID {
+			return inv, nil
+		}
+	case by.ClubBound() && by.CanAccessClub(inv.ClubID):
+		return inv, nil
+	}
+	return nil, entities.ErrInvoiceForbidden
+}
+
+func (u *invoiceInteractor) RenderPDF(inv *entities.Invoice) []byte {
+	return pdf.RenderInvoice(inv)
+}
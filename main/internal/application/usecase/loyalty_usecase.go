@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"math"
+	"time"
+)
+
+// LoyaltyUseCase defines business logic for the loyalty points program.
+type LoyaltyUseCase interface {
+	GetConfig(ctx context.Context, clubID string) (*entities.LoyaltyConfig, error)
+	SaveConfig(ctx context.Context, cfg *entities.LoyaltyConfig) error
+	// GetAccount returns the user's balance at clubID.
+	GetAccount(ctx context.Context, userID, clubID string) (*entities.LoyaltyAccount, error)
+	// GetAccounts returns the user's balance at every club they hold points at.
+	GetAccounts(ctx context.Context, userID string) ([]*entities.LoyaltyAccount, error)
+	GetTransactions(ctx context.Context, userID string) ([]*entities.LoyaltyTransaction, error)
+
+	// PointsDiscount returns how many of the requested points the user can
+	// burn on an order at clubID and what they are worth against amount.
+	PointsDiscount(ctx context.Context, userID, clubID string, requested int64, amount float64) (int64, float64, error)
+	// Burn debits points for a booking and returns the ledger entry ID.
+	Burn(ctx context.Context, userID, clubID string, points int64) (string, error)
+	// Restore gives burned points back; key makes the call idempotent.
+	Restore(ctx context.Context, userID, clubID string, points int64, key string) error
+	// Earn credits points for a completed booking and returns how many.
+	Earn(ctx context.Context, b *entities.Booking) (int64, error)
+	// Clawback removes the points earned by a refunded booking.
+	Clawback(ctx context.Context, b *entities.Booking) error
+	// ExpirePoints writes off all lots whose expiry has passed.
+	ExpirePoints(ctx context.Context) error
+}
+
+type loyaltyInteractor struct {
+	repo repository.LoyaltyRepository
+}
+
+// NewLoyaltyUseCase constructs a new LoyaltyUseCase with the given repository.
+func NewLoyaltyUseCase(r repository.LoyaltyRepository) LoyaltyUseCase {
+	return &loyaltyInteractor{repo: r}
+}
+
+func (u *loyaltyInteractor) GetConfig(ctx context.Context, clubID string) (*entities.LoyaltyConfig, error) {
+	return u.repo.FindConfig(ctx, clubID)
+}
+
+func (u *loyaltyInteractor) SaveConfig(ctx context.Context, cfg *entities.LoyaltyConfig) error {
+	if cfg.EarnPointsPerHour < 0 || cfg.PointValue < 0 || cfg.ExpiryDays < 0 {
+		return errors.New("rates and expiry must not be negative")
+	}
+	if cfg.MaxBurnPercent < 0 || cfg.MaxBurnPercent > 100 {
+		return errors.New("max_burn_percent must be between 0 and 100")
+	}
+	return u.repo.SaveConfig(ctx, cfg)
+}
+
+func (u *loyaltyInteractor) GetAccount(ctx context.Context, userID, clubID string) (*entities.LoyaltyAccount, error) {
+	return u.repo.FindAccount(ctx, userID, clubID)
+}
+
+func (u *loyaltyInteractor) GetAccounts(ctx context.Context, userID string) ([]*entities.LoyaltyAccount, error) {
+	return u.repo.FindAccounts(ctx, userID)
+}
+
+func (u *loyaltyInteractor) GetTransactions(ctx context.Context, userID string) ([]*entities.LoyaltyTransaction, error) {
+	return u.repo.FindTransactions(ctx, userID)
+}
+
+func (u *loyaltyInteractor) PointsDiscount(ctx context.Context, userID, clubID string, requested int64, amount float64) (int64, float64, error) {
+	if requested <= 0 || amount <= 0 {
+		return 0, 0, nil
+	}
+	cfg, err := u.repo.FindConfig(ctx, clubID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !cfg.Enabled || cfg.PointValue <= 0 {
+		return 0, 0, entities.ErrPointsNotAccepted
+	}
+	acc, err := u.repo.FindAccount(ctx, userID, clubID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if requested > acc.Balance {
+		return 0, 0, entities.ErrInsufficientPoints
+	}
+	maxValue := amount
+	if cfg.MaxBurnPercent > 0 {
+		maxValue = amount * cfg.MaxBurnPercent / 100
+	}
+	points := requested
+	if limit := int64(math.Floor(maxValue / cfg.PointValue)); points > limit {
+		points = limit
+	}
+	return points, float64(points) * cfg.PointValue, nil
+}
+
+func (u *loyaltyInteractor) Burn(ctx context.Context, userID, clubID string, points int64) (string, error) {
+	tx := &entities.LoyaltyTransaction{
+		UserID: userID,
+		ClubID: clubID,
+		Type:   entities.PointsBurn,
+		Points: -points,
+	}
+	if err := u.repo.Debit(ctx, "", tx, false); err != nil {
+		return "", err
+	}
+	return tx.ID, nil
+}
+
+// Restore returns points as a new lot that expires like freshly earned ones.
+func (u *loyaltyInteractor) Restore(ctx context.Context, userID, clubID string, points int64, key string) error {
+	cfg, err := u.repo.FindConfig(ctx, clubID)
+	if err != nil {
+		return err
+	}
+	tx := &entities.LoyaltyTransaction{
+		UserID: userID,
+		ClubID: clubID,
+		Type:   entities.PointsRestore,
+		Points: points,
+	}
+	if cfg.ExpiryDays > 0 {
+		tx.ExpiresAt = time.Now().AddDate(0, 0, cfg.ExpiryDays)
+	}
+	return u.repo.Credit(ctx, key, tx)
+}
+
+func (u *loyaltyInteractor) Earn(ctx context.Context, b *entities.Booking) (int64, error) {
+	cfg, err := u.repo.FindConfig(ctx, b.ClubID)
+	if err != nil {
+		return 0, err
+	}
+	if !cfg.Enabled || cfg.EarnPointsPerHour <= 0 {
+		return 0, nil
+	}
+	// only paid time earns points; membership-covered hours do not
+	var paidHours float64
+	for _, it := range b.LineItems {
+		if it.Kind == entities.LineItemTime {
+			paidHours += it.Quantity
+		}
+	}
+	points := int64(math.Floor(paidHours * cfg.EarnPointsPerHour))
+	if points <= 0 {
+		return 0, nil
+	}
+	tx := &entities.LoyaltyTransaction{
+		UserID:    b.UserID,
+		ClubID:    b.ClubID,
+		Type:      entities.PointsEarn,
+		Points:    points,
+		BookingID: b.ID,
+	}
+	if cfg.ExpiryDays > 0 {
+		tx.ExpiresAt = time.Now().AddDate(0, 0, cfg.ExpiryDays)
+	}
+	if err := u.repo.Credit(ctx, "earn_"+b.ID, tx); err != nil {
+		return 0, err
+	}
+	return points, nil
+}
+
+// Clawback debits as much of the earned points as the balance still holds;
+// points the user already spent are not recovered.
+func (u *loyaltyInteractor) Clawback(ctx context.Context, b *entities.Booking) error {
+	if b.PointsEarned <= 0 {
+		return nil
+	}
+	return u.repo.Debit(ctx, "clawback_"+b.ID, &entities.LoyaltyTransaction{
+		UserID:    b.UserID,
+		ClubID:    b.ClubID,
+		Type:      entities.PointsClawback,
+		Points:    -b.PointsEarned,
+		BookingID: b.ID,
+	}, true)
+}
+
+func (u *loyaltyInteractor) ExpirePoints(ctx context.Context) error {
+	lots, err := u.repo.FindExpiredLots(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, lot := range lots {
+		if err := u.repo.Expire(ctx, lot.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"main/internal/infrastructure/stripeclient"
+	"math"
+	"time"
+)
+
+// MembershipUseCase defines business logic for membership plans and user subscriptions.
+type MembershipUseCase interface {
+	GetPlans(ctx context.Context, clubID string) ([]*entities.MembershipPlan, error)
+	CreatePlan(ctx context.Context, p *entities.MembershipPlan) error
+	UpdatePlan(ctx context.Context, p *entities.MembershipPlan) error
+
+	GetByUser(ctx context.Context, userID string) ([]*entities.Membership, error)
+	// Subscribe starts a Stripe subscription for the plan and returns the
+	// membership together with the client secret of its first payment.
+	Subscribe(ctx context.Context, userID, planID string) (*entities.Membership, string, error)
+	Cancel(ctx context.Context, userID, id string) (*entities.Membership, error)
+	// SyncFromStripe applies a subscription state reported by a Stripe webhook.
+	SyncFromStripe(ctx context.Context, subscriptionID, status string, periodStart, periodEnd time.Time, cancelAtPeriodEnd bool) error
+
+	// Coverage returns the user's membership that covers the most of
+	// [start, end) at clubID and the number of hours it covers.
+	Coverage(ctx context.Context, userID, clubID string, start, end time.Time) (*entities.Membership, float64, error)
+	ConsumeHours(ctx context.Context, membershipID string, hours float64) error
+	// ReturnHours gives back used hours; a repeated call with the same
+	// non-empty key is a no-op.
+	ReturnHours(ctx context.Context, membershipID string, hours float64, key string) error
+}
+
+type membershipInteractor struct {
+	repo repository.MembershipRepository
+}
+
+// NewMembershipUseCase constructs a new MembershipUseCase with the given repository.
+func NewMembershipUseCase(r repository.MembershipRepository) MembershipUseCase {
+	return &membershipInteractor{repo: r}
+}
+
+func (u *membershipInteractor) GetPlans(ctx context.Context, clubID string) ([]*entities.MembershipPlan, error) {
+	return u.repo.FindPlansByClub(ctx, clubID)
+}
+
+func (u *membershipInteractor) CreatePlan(ctx context.Context, p *entities.MembershipPlan) error {
+	if err := validatePlan(p); err != nil {
+		return err
+	}
+	pr, err := stripeclient.CreateMonthlyPrice(p.Name, toMinorUnits(p.Price), p.Currency, int64(p.IntervalCount))
+	if err != nil {
+		return err
+	}
+	p.StripePriceID = pr.ID
+	p.CreatedAt = time.Now()
+	return u.repo.CreatePlan(ctx, p)
+}
+
+// UpdatePlan changes the plan terms for future subscribers; the Stripe price
+// is immutable, so it is kept as is. A plan of another club than p.ClubID
+// is reported as not found.
+func (u *membershipInteractor) UpdatePlan(ctx context.Context, p *entities.MembershipPlan) error {
+	existing, err := u.repo.FindPlanByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if existing.ClubID != p.ClubID {
+		return entities.ErrPlanNotFound
+	}
+	p.Price = existing.Price
+	p.Currency = existing.Currency
+	p.IntervalCount = existing.IntervalCount
+	p.StripePriceID = existing.StripePriceID
+	p.CreatedAt = existing.CreatedAt
+	if err := validatePlan(p); err != nil {
+		return err
+	}
+	return u.repo.UpdatePlan(ctx, p)
+}
+
+func (u *membershipInteractor) GetByUser(ctx context.Context, userID string) ([]*entities.Membership, error) {
+	return u.repo.FindByUser(ctx, userID)
+}
+
+func (u *membershipInteractor) Subscribe(ctx context.Context, userID, planID string) (*entities.Membership, string, error) {
+	plan, err := u.repo.FindPlanByID(ctx, planID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !plan.Active {
+		return nil, "", errors.New("plan is not available")
+	}
+
+	existing, err := u.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	var customerID string
+	for _, m := range existing {
+		if m.PlanID == planID && (m.Status == entities.MembershipActive || m.Status == entities.MembershipPastDue) {
+			return nil, "", errors.New("already subscribed to this plan")
+		}
+		if m.StripeCustomerID != "" {
+			customerID = m.StripeCustomerID
+		}
+	}
+	if customerID == "" {
+		cust, err := stripeclient.CreateCustomer(map[string]string{PaymentUserKey: userID})
+		if err != nil {
+			return nil, "", err
+		}
+		customerID = cust.ID
+	}
+
+	m := &entities.Membership{
+		UserID:           userID,
+		PlanID:           plan.ID,
+		ClubID:           plan.ClubID,
+		Status:           entities.MembershipIncomplete,
+		StripeCustomerID: customerID,
+		CreatedAt:        time.Now(),
+	}
+	if err := u.repo.Create(ctx, m); err != nil {
+		return nil, "", err
+	}
+	s, err := stripeclient.CreateSubscription(customerID, plan.StripePriceID, map[string]string{
+		PaymentUserKey:  userID,
+		"membership_id": m.ID,
+	})
+	if err != nil {
+		// nothing was subscribed, so the record would only be left behind
+		_ = u.repo.Delete(ctx, m.ID)
+		return nil, "", err
+	}
+	m.StripeSubscriptionID = s.ID
+	m.CurrentPeriodStart = time.Unix(s.CurrentPeriodStart, 0)
+	m.CurrentPeriodEnd = time.Unix(s.CurrentPeriodEnd, 0)
+	if err := u.repo.Update(ctx, m); err != nil {
+		return nil, "", err
+	}
+
+	var clientSecret string
+	if s.LatestInvoice != nil && s.LatestInvoice.PaymentIntent != nil {
+		clientSecret = s.LatestInvoice.PaymentIntent.ClientSecret
+	}
+	return m, clientSecret, nil
+}
+
+func (u *membershipInteractor) Cancel(ctx context.Context, userID, id string) (*entities.Membership, error) {
+	m, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if m.UserID != userID {
+		return nil, errors.New("membership belongs to another user")
+	}
+	if m.StripeSubscriptionID != "" {
+		if _, err := stripeclient.CancelSubscription(m.StripeSubscriptionID); err != nil {
+			return nil, err
+		}
+	}
+	m.CancelAtPeriodEnd = true
+	if err := u.repo.Update(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (u *membershipInteractor) SyncFromStripe(ctx context.Context, subscriptionID, status string, periodStart, periodEnd time.Time, cancelAtPeriodEnd bool) error {
+	m, err := u.repo.FindByStripeSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	// a new billing period means the subscription renewed
+	if !periodStart.Equal(m.CurrentPeriodStart) {
+		m.HoursUsed = 0
+	}
+	m.Status = membershipStatusFromStripe(status)
+	m.CurrentPeriodStart = periodStart
+	m.CurrentPeriodEnd = periodEnd
+	m.CancelAtPeriodEnd = cancelAtPeriodEnd
+	return u.repo.Update(ctx, m)
+}
+
+func (u *membershipInteractor) Coverage(ctx context.Context, userID, clubID string, start, end time.Time) (*entities.Membership, float64, error) {
+	list, err := u.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	var best *entities.Membership
+	var bestHours float64
+	for _, m := range list {
+		if m.ClubID != clubID || !m.UsableAt(start) {
+			continue
+		}
+		plan, err := u.repo.FindPlanByID(ctx, m.PlanID)
+		if err != nil {
+			return nil, 0, err
+		}
+		// the included hours belong to the current period, so time past
+		// its end is not covered by them
+		hours := plan.CoveredHours(start, minTime(end, m.CurrentPeriodEnd))
+		if !plan.Unlimited() {
+			hours = math.Min(hours, math.Max(plan.IncludedHours-m.HoursUsed, 0))
+		}
+		if hours > bestHours {
+			best, bestHours = m, hours
+		}
+	}
+	return best, bestHours, nil
+}
+
+func (u *membershipInteractor) ConsumeHours(ctx context.Context, membershipID string, hours float64) error {
+	m, err := u.repo.FindByID(ctx, membershipID)
+	if err != nil {
+		return err
+	}
+	plan, err := u.repo.FindPlanByID(ctx, m.PlanID)
+	if err != nil {
+		return err
+	}
+	var limit float64
+	if !plan.Unlimited() {
+		limit = plan.IncludedHours
+	}
+	return u.repo.ConsumeHours(ctx, membershipID, hours, limit)
+}
+
+func (u *membershipInteractor) ReturnHours(ctx context.Context, membershipID string, hours float64, key string) error {
+	return u.repo.ReturnHours(ctx, key, membershipID, hours)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func membershipStatusFromStripe(status string) string {
+	switch status {
+	case "active", "trialing":
+		return entities.MembershipActive
+	case "past_due", "unpaid":
+		return entities.MembershipPastDue
+	case "canceled":
+		return entities.MembershipCancelled
+	case "incomplete_expired":
+		return entities.MembershipExpired
+	}
+	return entities.MembershipIncomplete
+}
+
+func validatePlan(p *entities.MembershipPlan) error {
+	if p.ClubID == "" || p.Name == "" {
+		return errors.New("club_id and name are required")
+	}
+	if p.Price <= 0 || p.Currency == "" {
+		return errors.New("price and currency are required")
+	}
+	if p.IntervalCount <= 0 {
+		p.IntervalCount = 1
+	}
+	if p.IncludedHours < 0 {
+		return errors.New("included_hours must not be negative")
+	}
+	if p.StartHour < 0 || p.StartHour > 23 || p.EndHour < 0 || p.EndHour > 24 {
+		return errors.New("invalid hour window")
+	}
+	for _, d := range p.Weekdays {
+		if d < 0 || d > 6 {
+			return errors.New("weekdays must be 0 (Sunday) to 6 (Saturday)")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+const ledgerProvider = "stripe"
+
+// reconciliationSlack widens the lookup window on both sides so entries
+// recorded just across midnight from their provider counterpart still match.
+const reconciliationSlack = 24 * time.Hour
+
+// PaymentLedgerUseCase records provider money movements and reconciles them
+// against what the provider reports.
+type PaymentLedgerUseCase interface {
+	RecordIntent(ctx context.Context, intentID string, amount int64, currency string, metadata map[string]string) error
+	// RecordCharge records a succeeded charge and, when balanceTxnID is known,
+	// the provider fee taken on it.
+	RecordCharge(ctx context.Context, chargeID, intentID string, amount int64, currency, balanceTxnID string) error
+	RecordRefund(ctx context.Context, refundID, intentID string, amount int64, currency string) error
+	// RecordOffline records money taken (LedgerCharge) or handed back
+	// (LedgerRefund) at the front desk for a booking.
+	RecordOffline(ctx context.Context, entryType string, b *entities.Booking, staffID string) error
+	GetByBooking(ctx context.Context, bookingID string) ([]*entities.PaymentLedgerEntry, error)
+
+	// Reconcile compares the ledger with the provider for the UTC day
+	// containing day and stores the report.
+	Reconcile(ctx context.Context, day time.Time) (*entities.ReconciliationReport, error)
+	GetReconciliation(ctx context.Context, id string) (*entities.ReconciliationReport, error)
+	// ReconcileYesterday is the entry point of the daily job.
+	ReconcileYesterday(ctx context.Context) error
+}
+
+type paymentLedgerInteractor struct {
+	repo    repository.PaymentLedgerRepository
+	gateway repository.PaymentGateway
+}
+
+// NewPaymentLedgerUseCase constructs a new PaymentLedgerUseCase.
+func NewPaymentLedgerUseCase(r repository.PaymentLedgerRepository, g repository.PaymentGateway) PaymentLedgerUseCase {
+	return &paymentLedgerInteractor{repo: r, gateway: g}
+}
+
+func (u *paymentLedgerInteractor) RecordIntent(ctx context.Context, intentID string, amount int64, currency string, metadata map[string]string) error {
+	return u.repo.Record(ctx, "intent_"+intentID, &entities.PaymentLedgerEntry{
+		Type:        entities.LedgerIntent,
+		Provider:    ledgerProvider,
+		ProviderRef: intentID,
+		IntentRef:   intentID,
+		Purpose:     metadata[PaymentPurposeKey],
+		BookingID:   metadata[PaymentBookingKey],
+		UserID:      metadata[PaymentUserKey],
+		Amount:      amount,
+		Currency:    currency,
+		CreatedAt:   time.Now(),
+	})
+}
+
+func (u *paymentLedgerInteractor) RecordCharge(ctx context.Context, chargeID, intentID string, amount int64, currency, balanceTxnID string) error {
+	e := u.entryFor(ctx, intentID)
+	e.Type = entities.LedgerCharge
+	e.ProviderRef = chargeID
+	e.Amount = amount
+	e.Currency = currency
+	if err := u.repo.Record(ctx, "charge_"+chargeID, e); err != nil {
+		return err
+	}
+	if balanceTxnID == "" {
+		return nil
+	}
+	bt, err := u.gateway.BalanceTransaction(ctx, balanceTxnID)
+	if err != nil {
+		return err
+	}
+	fee := u.entryFor(ctx, intentID)
+	fee.Type = entities.LedgerFee
+	fee.ProviderRef = bt.ID
+	fee.Amount = -bt.Fee
+	fee.Currency = bt.Currency
+	return u.repo.Record(ctx, "fee_"+bt.ID, fee)
+}
+
+func (u *paymentLedgerInteractor) RecordOffline(ctx context.Context, entryType string, b *entities.Booking, staffID string) error {
+	amount := toMinorUnits(b.TotalPrice)
+	if entryType == entities.LedgerRefund {
+		amount = -amount
+	}
+	return u.repo.Record(ctx, "offline_"+entryType+"_"+b.ID, &entities.PaymentLedgerEntry{
+		Type:        entryType,
+		Provider:    b.PaymentMethod,
+		ProviderRef: b.PaymentRef,
+		Purpose:     PaymentPurposeBooking,
+		BookingID:   b.ID,
+		ClubID:      b.ClubID,
+		UserID:      b.UserID,
+		StaffID:     staffID,
+		Amount:      amount,
+		Currency:    DefaultCurrency,
+		CreatedAt:   time.Now(),
+	})
+}
+
+func (u *paymentLedgerInteractor) RecordRefund(ctx context.Context, refundID, intentID string, amount int64, currency string) error {
+	e := u.entryFor(ctx, intentID)
+	e.Type = entities.LedgerRefund
+	e.ProviderRef = refundID
+	e.Amount = -amount
+	e.Currency = currency
+	return u.repo.Record(ctx, "refund_"+refundID, e)
+}
+
+// entryFor starts an entry attributed to whatever the intent paid for.
+func (u *paymentLedgerInteractor) entryFor(ctx context.Context, intentID string) *entities.PaymentLedgerEntry {
+	e := &entities.PaymentLedgerEntry{
+		Provider:  ledgerProvider,
+		IntentRef: intentID,
+		CreatedAt: time.Now(),
+	}
+	if intentID == "" {
+		return e
+	}
+	if intent, err := u.repo.FindByProviderRef(ctx, intentID); err == nil {
+		e.Purpose = intent.Purpose
+		e.BookingID = intent.BookingID
+		e.UserID = intent.UserID
+	}
+	return e
+}
+
+func (u *paymentLedgerInteractor) GetByBooking(ctx context.Context, bookingID string) ([]*entities.PaymentLedgerEntry, error) {
+	return u.repo.FindByBooking(ctx, bookingID)
+}
+
+func (u *paymentLedgerInteractor) Reconcile(ctx context.Context, day time.Time) (*entities.ReconciliationReport, error) {
+	from := day.UTC().Truncate(24 * time.Hour)
+	to := from.Add(24 * time.Hour)
+	inWindow := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+	provider, err := u.gateway.BalanceTransactions(ctx, from.Add(-reconciliationSlack), to.Add(reconciliationSlack))
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := u.repo.FindByPeriod(ctx, from.Add(-reconciliationSlack), to.Add(reconciliationSlack))
+	if err != nil {
+		return nil, err
+	}
+	// offline payments never reach the provider
+	stripe := ledger[:0]
+	for _, e := range ledger {
+		if e.Provider == ledgerProvider {
+			stripe = append(stripe, e)
+		}
+	}
+	ledger = stripe
+	byRef := map[string]*entities.PaymentLedgerEntry{}
+	for _, e := range ledger {
+		if e.Type != entities.LedgerIntent {
+			byRef[e.ProviderRef] = e
+		}
+	}
+
+	rep := &entities.ReconciliationReport{
+		ID:         from.Format("2006-01-02"),
+		From:       from,
+		To:         to,
+		Mismatches: []entities.ReconciliationMismatch{},
+		CreatedAt:  time.Now(),
+	}
+	seen := map[string]bool{}
+	for _, pt := range provider {
+		// payouts and other balance movements are not tracked in the ledger
+		if pt.Type != "charge" && pt.Type != "payment" && pt.Type != "refund" {
+			continue
+		}
+		money, fee := byRef[pt.SourceID], byRef[pt.ID]
+		if money != nil {
+			seen[money.ProviderRef] = true
+		}
+		if fee != nil {
+			seen[fee.ProviderRef] = true
+		}
+		if !inWindow(pt.Created) {
+			continue
+		}
+		rep.ProviderCount++
+		if money == nil {
+			rep.Mismatches = append(rep.Mismatches, entities.ReconciliationMismatch{
+				Kind:           entities.MismatchMissingInLedger,
+				ProviderRef:    pt.SourceID,
+				ProviderAmount: pt.Amount,
+			})
+			continue
+		}
+		ok := true
+		if money.Amount != pt.Amount {
+			ok = false
+			rep.Mismatches = append(rep.Mismatches, entities.ReconciliationMismatch{
+				Kind:           entities.MismatchAmount,
+				ProviderRef:    pt.SourceID,
+				LedgerAmount:   money.Amount,
+				ProviderAmount: pt.Amount,
+			})
+		}
+		var ledgerFee int64
+		if fee != nil {
+			ledgerFee = -fee.Amount
+		}
+		if ledgerFee != pt.Fee {
+			ok = false
+			rep.Mismatches = append(rep.Mismatches, entities.ReconciliationMismatch{
+				Kind:           entities.MismatchFee,
+				ProviderRef:    pt.ID,
+				LedgerAmount:   ledgerFee,
+				ProviderAmount: pt.Fee,
+			})
+		}
+		if ok {
+			rep.Matched++
+		}
+	}
+	for _, e := range ledger {
+		if e.Type == entities.LedgerIntent || !inWindow(e.CreatedAt) {
+			continue
+		}
+		if e.Type != entities.LedgerFee {
+			rep.LedgerCount++
+		}
+		if !seen[e.ProviderRef] {
+			rep.Mismatches = append(rep.Mismatches, entities.ReconciliationMismatch{
+				Kind:         entities.MismatchMissingAtProvider,
+				ProviderRef:  e.ProviderRef,
+				LedgerAmount: e.Amount,
+			})
+		}
+	}
+
+	if err := u.repo.SaveReconciliation(ctx, rep); err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
+
+func (u *paymentLedgerInteractor) GetReconciliation(ctx context.Context, id string) (*entities.ReconciliationReport, error) {
+	return u.repo.FindReconciliation(ctx, id)
+}
+
+func (u *paymentLedgerInteractor) ReconcileYesterday(ctx context.Context) error {
+	_, err := u.Reconcile(ctx, time.Now().UTC().Add(-24*time.Hour))
+	return err
+}
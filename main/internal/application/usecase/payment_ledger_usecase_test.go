@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"main/internal/domain/entities"
+)
+
+// fakeGateway serves provider balance transactions from memory.
+type fakeGateway struct {
+	txns []*entities.ProviderTransaction
+}
+
+func (g *fakeGateway) BalanceTransaction(ctx context.Context, id string) (*entities.ProviderTransaction, error) {
+	for _, t := range g.txns {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, errors.New("no such balance transaction")
+}
+
+func (g *fakeGateway) BalanceTransactions(ctx context.Context, from, to time.Time) ([]*entities.ProviderTransaction, error) {
+	var out []*entities.ProviderTransaction
+	for _, t := range g.txns {
+		if !t.Created.Before(from) && t.Created.Before(to) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// fakeLedgerRepo keeps ledger entries and reports in memory.
+type fakeLedgerRepo struct {
+	entries map[string]*entities.PaymentLedgerEntry
+	reports map[string]*entities.ReconciliationReport
+}
+
+func newFakeLedgerRepo() *fakeLedgerRepo {
+	return &fakeLedgerRepo{
+		entries: map[string]*entities.PaymentLedgerEntry{},
+		reports: map[string]*entities.ReconciliationReport{},
+	}
+}
+
+func (r *fakeLedgerRepo) Record(ctx context.Context, key string, e *entities.PaymentLedgerEntry) error {
+	if _, ok := r.entries[key]; ok {
+		e.ID = ""
+		return nil
+	}
+	e.ID = key
+	r.entries[key] = e
+	return nil
+}
+
+func (r *fakeLedgerRepo) FindByProviderRef(ctx context.Context, ref string) (*entities.PaymentLedgerEntry, error) {
+	for _, e := range r.entries {
+		if e.ProviderRef == ref {
+			return e, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (r *fakeLedgerRepo) FindByBooking(ctx context.Context, bookingID string) ([]*entities.PaymentLedgerEntry, error) {
+	return r.filter(func(e *entities.PaymentLedgerEntry) bool { return e.BookingID == bookingID }), nil
+}
+
+func (r *fakeLedgerRepo) FindByPeriod(ctx context.Context, from, to time.Time) ([]*entities.PaymentLedgerEntry, error) {
+	return r.filter(func(e *entities.PaymentLedgerEntry) bool {
+		return !e.CreatedAt.Before(from) && e.CreatedAt.Before(to)
+	}), nil
+}
+
+func (r *fakeLedgerRepo) FindByClub(ctx context.Context, clubID string, from, to time.Time) ([]*entities.PaymentLedgerEntry, error) {
+	return r.filter(func(e *entities.PaymentLedgerEntry) bool {
+		return e.ClubID == clubID && !e.CreatedAt.Before(from) && e.CreatedAt.Before(to)
+	}), nil
+}
+
+func (r *fakeLedgerRepo) SaveReconciliation(ctx context.Context, rep *entities.ReconciliationReport) error {
+	r.reports[rep.ID] = rep
+	return nil
+}
+
+func (r *fakeLedgerRepo) FindReconciliation(ctx context.Context, id string) (*entities.ReconciliationReport, error) {
+	rep, ok := r.reports[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return rep, nil
+}
+
+func (r *fakeLedgerRepo) filter(keep func(*entities.PaymentLedgerEntry) bool) []*entities.PaymentLedgerEntry {
+	var out []*entities.PaymentLedgerEntry
+	for _, e := range r.entries {
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+var reconDay = time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+// entry returns a Stripe ledger entry created at noon of reconDay.
+func entry(typ, ref string, amount int64) *entities.PaymentLedgerEntry {
+	return &entities.PaymentLedgerEntry{
+		Type:        typ,
+		Provider:    ledgerProvider,
+		ProviderRef: ref,
+		Amount:      amount,
+		Currency:    "kzt",
+		CreatedAt:   reconDay.Add(12 * time.Hour),
+	}
+}
+
+// txn returns a provider balance transaction created at noon of reconDay.
+func txn(id, typ, source string, amount, fee int64) *entities.ProviderTransaction {
+	return &entities.ProviderTransaction{
+		ID:       id,
+		Type:     typ,
+		SourceID: source,
+		Amount:   amount,
+		Fee:      fee,
+		Currency: "kzt",
+		Created:  reconDay.Add(12 * time.Hour),
+	}
+}
+
+func reconcile(t *testing.T, repo *fakeLedgerRepo, g *fakeGateway) *entities.ReconciliationReport {
+	t.Helper()
+	rep, err := NewPaymentLedgerUseCase(repo, g).Reconcile(context.Background(), reconDay.Add(8*time.Hour))
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if repo.reports[rep.ID] != rep {
+		t.Errorf("report %s was not saved", rep.ID)
+	}
+	return rep
+}
+
+func mismatchKinds(rep *entities.ReconciliationReport) map[string]string {
+	out := map[string]string{}
+	for _, m := range rep.Mismatches {
+		out[m.ProviderRef] = m.Kind
+	}
+	return out
+}
+
+func TestReconcileMatchesChargesFeesAndRefunds(t *testing.T) {
+	repo := newFakeLedgerRepo()
+	repo.Record(context.Background(), "intent_pi_1", entry(entities.LedgerIntent, "pi_1", 5000))
+	repo.Record(context.Background(), "charge_ch_1", entry(entities.LedgerCharge, "ch_1", 5000))
+	repo.Record(context.Background(), "fee_txn_1", entry(entities.LedgerFee, "txn_1", -150))
+	repo.Record(context.Background(), "refund_re_1", entry(entities.LedgerRefund, "re_1", -5000))
+	g := &fakeGateway{txns: []*entities.ProviderTransaction{
+		txn("txn_1", "charge", "ch_1", 5000, 150),
+		txn("txn_2", "refund", "re_1", -5000, 0),
+		txn("po_1", "payout", "po_1", -10000, 0),
+	}}
+
+	rep := reconcile(t, repo, g)
+	if len(rep.Mismatches) != 0 {
+		t.Fatalf("mismatches = %+v, want none", rep.Mismatches)
+	}
+	if rep.ProviderCount != 2 || rep.LedgerCount != 2 || rep.Matched != 2 {
+		t.Errorf("counts provider=%d ledger=%d matched=%d, want 2/2/2", rep.ProviderCount, rep.LedgerCount, rep.Matched)
+	}
+	if rep.ID != "2026-03-14" {
+		t.Errorf("ID = %q, want 2026-03-14", rep.ID)
+	}
+}
+
+func TestReconcileReportsMismatches(t *testing.T) {
+	repo := newFakeLedgerRepo()
+	// amount differs from the provider
+	repo.Record(context.Background(), "charge_ch_1", entry(entities.LedgerCharge, "ch_1", 5000))
+	repo.Record(context.Background(), "fee_txn_1", entry(entities.LedgerFee, "txn_1", -150))
+	// fee was never recorded
+	repo.Record(context.Background(), "charge_ch_2", entry(entities.LedgerCharge, "ch_2", 3000))
+	// the provider has no record of this refund
+	repo.Record(context.Background(), "refund_re_9", entry(entities.LedgerRefund, "re_9", -1000))
+	g := &fakeGateway{txns: []*entities.ProviderTransaction{
+		txn("txn_1", "charge", "ch_1", 4500, 150),
+		txn("txn_2", "charge", "ch_2", 3000, 90),
+		// a charge the webhook never delivered
+		txn("txn_3", "charge", "ch_3", 2000, 60),
+	}}
+
+	rep := reconcile(t, repo, g)
+	want := map[string]string{
+		"ch_1":  entities.MismatchAmount,
+		"txn_2": entities.MismatchFee,
+		"ch_3":  entities.MismatchMissingInLedger,
+		"re_9":  entities.MismatchMissingAtProvider,
+	}
+	got := mismatchKinds(rep)
+	if len(got) != len(want) {
+		t.Fatalf("mismatches = %+v, want %v", rep.Mismatches, want)
+	}
+	for ref, kind := range want {
+		if got[ref] != kind {
+			t.Errorf("mismatch for %s = %q, want %q", ref, got[ref], kind)
+		}
+	}
+	if rep.Matched != 0 {
+		t.Errorf("Matched = %d, want 0", rep.Matched)
+	}
+}
+
+func TestReconcileSkipsOfflineAndOtherDays(t *testing.T) {
+	repo := newFakeLedgerRepo()
+	cash := entry(entities.LedgerCharge, "cash_b1", 2000)
+	cash.Provider = entities.PaymentMethodCash
+	repo.Record(context.Background(), "offline_charge_b1", cash)
+	// recorded just before midnight, settled by the provider just after
+	late := entry(entities.LedgerCharge, "ch_1", 5000)
+	late.CreatedAt = reconDay.Add(-time.Minute)
+	repo.Record(context.Background(), "charge_ch_1", late)
+	g := &fakeGateway{txns: []*entities.ProviderTransaction{
+		txn("txn_1", "charge", "ch_1", 5000, 0),
+	}}
+	g.txns[0].Created = reconDay.Add(time.Minute)
+
+	rep := reconcile(t, repo, g)
+	if len(rep.Mismatches) != 0 {
+		t.Fatalf("mismatches = %+v, want none", rep.Mismatches)
+	}
+	if rep.ProviderCount != 1 || rep.LedgerCount != 0 || rep.Matched != 1 {
+		t.Errorf("counts provider=%d ledger=%d matched=%d, want 1/0/1", rep.ProviderCount, rep.LedgerCount, rep.Matched)
+	}
+}
+
+func TestRecordChargeRecordsProviderFee(t *testing.T) {
+	repo := newFakeLedgerRepo()
+	g := &fakeGateway{txns: []*entities.ProviderTransaction{
+		txn("txn_1", "charge", "ch_1", 5000, 150),
+	}}
+	uc := NewPaymentLedgerUseCase(repo, g)
+	ctx := context.Background()
+	if err := uc.RecordIntent(ctx, "pi_1", 5000, "kzt", map[string]string{PaymentBookingKey: "b1"}); err != nil {
+		t.Fatal(err)
+	}
+	// webhooks are delivered at least once
+	for i := 0; i < 2; i++ {
+		if err := uc.RecordCharge(ctx, "ch_1", "pi_1", 5000, "kzt", "txn_1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fee := repo.entries["fee_txn_1"]
+	if fee == nil || fee.Amount != -150 || fee.BookingID != "b1" {
+		t.Fatalf("fee entry = %+v, want -150 for booking b1", fee)
+	}
+	if len(repo.entries) != 3 {
+		t.Errorf("%d entries, want intent, charge and fee", len(repo.entries))
+	}
+}
@@ -2,29 +2,117 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"main/internal/domain/entities"
+	"math"
+	"time"
 
 	"main/internal/infrastructure/stripeclient"
 )
 
+// DefaultCurrency is the currency bookings are priced and charged in.
+const DefaultCurrency = "kzt"
+
 type PaymentUseCase interface {
 	CreateIntent(ctx context.Context, amount int64, currency string) (string, error)
+	// HandleIntentSucceeded processes a succeeded PaymentIntent reported by
+	// the webhook. amount is in the currency's minor units.
+	HandleIntentSucceeded(ctx context.Context, intentID string, amount int64, metadata map[string]string) error
+	// HandleCheckoutCompleted processes checkout.session.completed; unpaid
+	// sessions (delayed payment methods) are left to payment_intent.succeeded.
+	HandleCheckoutCompleted(ctx context.Context, sessionID, intentID, paymentStatus string, amount int64, currency string, metadata map[string]string) error
+	HandleCheckoutExpired(ctx context.Context, sessionID string, metadata map[string]string) error
+	// HandleSubscriptionUpdated processes customer.subscription.* webhooks.
+	HandleSubscriptionUpdated(ctx context.Context, subscriptionID, status string, periodStart, periodEnd int64, cancelAtPeriodEnd bool) error
+	// HandleChargeSucceeded and HandleRefund record charge.* webhooks in the ledger.
+	HandleChargeSucceeded(ctx context.Context, chargeID, intentID string, amount int64, currency, balanceTxnID string) error
+	HandleRefund(ctx context.Context, refundID, intentID string, amount int64, currency string) error
 }
 
 type paymentInteractor struct {
+	walletUC     WalletUseCase
+	membershipUC MembershipUseCase
+	bookingUC    BookingUseCase
+	ledgerUC     PaymentLedgerUseCase
 }
 
-func NewPaymentUseCase() PaymentUseCase {
-	return &paymentInteractor{}
+func NewPaymentUseCase(
+	walletUC WalletUseCase,
+	membershipUC MembershipUseCase,
+	bookingUC BookingUseCase,
+	ledgerUC PaymentLedgerUseCase,
+) PaymentUseCase {
+	return &paymentInteractor{
+		walletUC:     walletUC,
+		membershipUC: membershipUC,
+		bookingUC:    bookingUC,
+		ledgerUC:     ledgerUC,
+	}
 }
 
 func (u *paymentInteractor) CreateIntent(ctx context.Context, amount int64, currency string) (string, error) {
 	if amount <= 0 {
-		return "", errors.New("invalid amount")
+		return "", ErrInvalidAmount
 	}
 	pi, err := stripeclient.CreatePaymentIntent(amount, currency, nil)
 	if err != nil {
 		return "", err
 	}
+	if err := u.ledgerUC.RecordIntent(ctx, pi.ID, pi.Amount, string(pi.Currency), pi.Metadata); err != nil {
+		return "", err
+	}
 	return pi.ClientSecret, nil
 }
+
+func (u *paymentInteractor) HandleIntentSucceeded(ctx context.Context, intentID string, amount int64, metadata map[string]string) error {
+	switch metadata[PaymentPurposeKey] {
+	case PaymentPurposeTopUp:
+		return u.walletUC.TopUp(ctx, metadata[PaymentUserKey], fromMinorUnits(amount), intentID)
+	case PaymentPurposeBooking:
+		return u.bookingUC.ConfirmPayment(ctx, metadata[PaymentBookingKey],
+			entities.PaymentMethodCard, intentID, fromMinorUnits(amount))
+	}
+	return nil
+}
+
+func (u *paymentInteractor) HandleCheckoutCompleted(ctx context.Context, sessionID, intentID, paymentStatus string, amount int64, currency string, metadata map[string]string) error {
+	if paymentStatus != "paid" || metadata[PaymentPurposeKey] != PaymentPurposeBooking {
+		return nil
+	}
+	if err := u.ledgerUC.RecordIntent(ctx, intentID, amount, currency, metadata); err != nil {
+		return err
+	}
+	// the booking keeps the PaymentIntent as its reference so card refunds
+	// work the same for both flows
+	return u.bookingUC.ConfirmPayment(ctx, metadata[PaymentBookingKey],
+		entities.PaymentMethodCard, intentID, fromMinorUnits(amount))
+}
+
+func (u *paymentInteractor) HandleCheckoutExpired(ctx context.Context, sessionID string, metadata map[string]string) error {
+	if metadata[PaymentPurposeKey] != PaymentPurposeBooking {
+		return nil
+	}
+	return u.bookingUC.ExpireCheckout(ctx, metadata[PaymentBookingKey], sessionID)
+}
+
+func (u *paymentInteractor) HandleSubscriptionUpdated(ctx context.Context, subscriptionID, status string, periodStart, periodEnd int64, cancelAtPeriodEnd bool) error {
+	return u.membershipUC.SyncFromStripe(ctx, subscriptionID, status,
+		time.Unix(periodStart, 0), time.Unix(periodEnd, 0), cancelAtPeriodEnd)
+}
+
+func (u *paymentInteractor) HandleChargeSucceeded(ctx context.Context, chargeID, intentID string, amount int64, currency, balanceTxnID string) error {
+	return u.ledgerUC.RecordCharge(ctx, chargeID, intentID, amount, currency, balanceTxnID)
+}
+
+func (u *paymentInteractor) HandleRefund(ctx context.Context, refundID, intentID string, amount int64, currency string) error {
+	return u.ledgerUC.RecordRefund(ctx, refundID, intentID, amount, currency)
+}
+
+// toMinorUnits converts a major-unit amount to Stripe's integer minor units.
+func toMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// fromMinorUnits converts Stripe's integer minor units to a major-unit amount.
+func fromMinorUnits(amount int64) float64 {
+	return float64(amount) / 100
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"math"
+)
+
+var (
+	ErrInvalidInterval = errors.New("end_time must be after start_time")
+	ErrInvalidClub     = errors.New("invalid club ID")
+	ErrUnknownComputer = errors.New("computer not found in club")
+)
+
+// PricingUseCase computes quotes for prospective bookings.
+type PricingUseCase interface {
+	Quote(ctx context.Context, req *entities.QuoteRequest) (*entities.Quote, error)
+}
+
+type pricingInteractor struct {
+	clubRepo     repository.ClubRepository
+	compRepo     repository.ComputerRepository
+	promoUC      PromoCodeUseCase
+	membershipUC MembershipUseCase
+	loyaltyUC    LoyaltyUseCase
+}
+
+// NewPricingUseCase constructs a new PricingUseCase.
+func NewPricingUseCase(
+	cRepo repository.ClubRepository,
+	compRepo repository.ComputerRepository,
+	promoUC PromoCodeUseCase,
+	membershipUC MembershipUseCase,
+	loyaltyUC LoyaltyUseCase,
+) PricingUseCase {
+	return &pricingInteractor{
+		clubRepo:     cRepo,
+		compRepo:     compRepo,
+		promoUC:      promoUC,
+		membershipUC: membershipUC,
+		loyaltyUC:    loyaltyUC,
+	}
+}
+
+func (u *pricingInteractor) Quote(ctx context.Context, req *entities.QuoteRequest) (*entities.Quote, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, ErrInvalidInterval
+	}
+	if req.EndTime.Sub(req.StartTime) > entities.MaxBookingDuration {
+		return nil, entities.ErrBookingTooLong
+	}
+	club, err := u.clubRepo.FindByID(ctx, req.ClubID)
+	if err != nil {
+		return nil, ErrInvalidClub
+	}
+	comp, err := u.findComputer(ctx, req.ClubID, req.PCNumber)
+	if err != nil {
+		return nil, err
+	}
+	if comp.InMaintenanceDuring(req.StartTime, req.EndTime) {
+		return nil, entities.ErrComputerInService
+	}
+	rate := club.HourlyRate(comp.Category)
+	label := fmt.Sprintf("PC #%d", req.PCNumber)
+	if comp.Category != "" && comp.Category != entities.CategoryStandard {
+		label += " (" + comp.Category + ")"
+	}
+
+	// schedules and membership windows are wall-clock times at the club
+	loc := club.TZ()
+	start, end := req.StartTime.In(loc), req.EndTime.In(loc)
+	if err := club.Hours.Check(start, end); err != nil {
+		return nil, err
+	}
+
+	hours := end.Sub(start).Hours()
+	q := &entities.Quote{
+		ClubID:     req.ClubID,
+		PCNumber:   req.PCNumber,
+		StartTime:  start,
+		EndTime:    end,
+		Timezone:   loc.String(),
+		StartLocal: start.Format(entities.LocalLayout),
+		EndLocal:   end.Format(entities.LocalLayout),
+		Hours:      hours,
+	}
+
+	// hours included in a membership are listed as a zero-cost line item
+	paidHours := hours
+	if req.UserID != "" {
+		m, covered, err := u.membershipUC.Coverage(ctx, req.UserID, req.ClubID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil && covered > 0 {
+			q.LineItems = append(q.LineItems, entities.LineItem{
+				Kind:        entities.LineItemMembership,
+				Description: fmt.Sprintf("PC #%d (membership)", req.PCNumber),
+				Quantity:    covered,
+				UnitPrice:   0,
+				Amount:      0,
+			})
+			q.MembershipID = m.ID
+			q.CoveredHours = covered
+			paidHours -= covered
+		}
+	}
+	if paidHours > 0 {
+		q.LineItems = append(q.LineItems, entities.LineItem{
+			Kind:        entities.LineItemTime,
+			Description: label,
+			Quantity:    paidHours,
+			UnitPrice:   rate,
+			Amount:      paidHours * rate,
+		})
+	}
+	q.Subtotal = sumLineItems(q.LineItems)
+
+	if req.PromoCode != "" {
+		promo, err := u.promoUC.Validate(ctx, req.PromoCode, req.UserID, req.ClubID, q.Subtotal)
+		if err != nil {
+			return nil, err
+		}
+		discount := promo.DiscountFor(q.Subtotal, rate)
+		q.LineItems = append(q.LineItems, entities.LineItem{
+			Kind:        entities.LineItemDiscount,
+			Description: "Promo code " + promo.Code,
+			Quantity:    1,
+			UnitPrice:   -discount,
+			Amount:      -discount,
+		})
+		q.Discount += discount
+		q.PromoCodeID = promo.ID
+		q.PromoCode = promo.Code
+	}
+
+	// loyalty points are burned against what is left after other discounts
+	if req.RedeemPoints > 0 {
+		points, value, err := u.loyaltyUC.PointsDiscount(ctx, req.UserID, req.ClubID, req.RedeemPoints, q.Subtotal-q.Discount)
+		if err != nil {
+			return nil, err
+		}
+		if points > 0 {
+			q.LineItems = append(q.LineItems, entities.LineItem{
+				Kind:        entities.LineItemPoints,
+				Description: fmt.Sprintf("%d loyalty points", points),
+				Quantity:    float64(points),
+				UnitPrice:   -value / float64(points),
+				Amount:      -value,
+			})
+			q.Discount += value
+			q.PointsUsed = points
+		}
+	}
+
+	// tax is applied to the discounted amount; Split handles exempt clubs
+	net := roundMoney(q.Subtotal - q.Discount)
+	tax, total := club.Tax.Split(net)
+	q.TaxAmount = roundMoney(tax)
+	q.Total = roundMoney(total)
+	if !club.Tax.Exempt {
+		q.TaxRate = club.Tax.Rate
+		q.TaxInclusive = club.Tax.Inclusive
+	}
+	if q.TaxAmount > 0 && !club.Tax.Inclusive {
+		q.LineItems = append(q.LineItems, entities.LineItem{
+			Kind:        entities.LineItemTax,
+			Description: fmt.Sprintf("VAT %g%%", club.Tax.Rate),
+			Quantity:    1,
+			UnitPrice:   q.TaxAmount,
+			Amount:      q.TaxAmount,
+		})
+	}
+	return q, nil
+}
+
+// roundMoney rounds to whole minor units.
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func (u *pricingInteractor) findComputer(ctx context.Context, clubID string, pcNumber int) (*entities.Computer, error) {
+	comps, err := u.compRepo.FindByClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comps {
+		if c.PCNumber == pcNumber {
+			return c, nil
+		}
+	}
+	return nil, ErrUnknownComputer
+}
+
+func sumLineItems(items []entities.LineItem) float64 {
+	var total float64
+	for _, it := range items {
+		total += it.Amount
+	}
+	return total
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"strings"
+	"time"
+)
+
+// PromoCodeUseCase defines business logic for PromoCode.
+type PromoCodeUseCase interface {
+	GetAll(ctx context.Context) ([]*entities.PromoCode, error)
+	GetByID(ctx context.Context, id string) (*entities.PromoCode, error)
+	Create(ctx context.Context, p *entities.PromoCode) error
+	Update(ctx context.Context, p *entities.PromoCode) error
+	Delete(ctx context.Context, id string) error
+	// Validate checks whether userID may apply code to an order at clubID
+	// with the given subtotal. It does not consume a use.
+	Validate(ctx context.Context, code, userID, clubID string, subtotal float64) (*entities.PromoCode, error)
+	// Redeem consumes one use of the code and returns the redemption ID.
+	Redeem(ctx context.Context, promoID, userID string) (string, error)
+	Release(ctx context.Context, redemptionID string) error
+}
+
+type promoCodeInteractor struct {
+	repo        repository.PromoCodeRepository
+	bookingRepo repository.BookingRepository
+}
+
+// NewPromoCodeUseCase constructs a new PromoCodeUseCase with the given repositories.
+func NewPromoCodeUseCase(r repository.PromoCodeRepository, bRepo repository.BookingRepository) PromoCodeUseCase {
+	return &promoCodeInteractor{repo: r, bookingRepo: bRepo}
+}
+
+func (u *promoCodeInteractor) GetAll(ctx context.Context) ([]*entities.PromoCode, error) {
+	return u.repo.FindAll(ctx)
+}
+
+func (u *promoCodeInteractor) GetByID(ctx context.Context, id string) (*entities.PromoCode, error) {
+	return u.repo.FindByID(ctx, id)
+}
+
+func (u *promoCodeInteractor) Create(ctx context.Context, p *entities.PromoCode) error {
+	if err := validatePromoCode(p); err != nil {
+		return err
+	}
+	if _, err := u.repo.FindByCode(ctx, p.Code); err == nil {
+		return errors.New("promo code already exists")
+	} else if !errors.Is(err, entities.ErrPromoCodeNotFound) {
+		return err
+	}
+	p.UsedCount = 0
+	p.CreatedAt = time.Now()
+	return u.repo.Create(ctx, p)
+}
+
+func (u *promoCodeInteractor) Update(ctx context.Context, p *entities.PromoCode) error {
+	if err := validatePromoCode(p); err != nil {
+		return err
+	}
+	existing, err := u.repo.FindByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	// usage counters are owned by Redeem/Release
+	p.UsedCount = existing.UsedCount
+	p.CreatedAt = existing.CreatedAt
+	return u.repo.Update(ctx, p)
+}
+
+func (u *promoCodeInteractor) Delete(ctx context.Context, id string) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *promoCodeInteractor) Validate(ctx context.Context, code, userID, clubID string, subtotal float64) (*entities.PromoCode, error) {
+	p, err := u.repo.FindByCode(ctx, normalizePromoCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !p.Active {
+		return nil, entities.ErrPromoCodeInactive
+	}
+	if !p.ValidAt(time.Now()) {
+		return nil, entities.ErrPromoCodeExpired
+	}
+	if !p.AppliesToClub(clubID) {
+		return nil, entities.ErrPromoCodeWrongClub
+	}
+	if subtotal < p.MinSpend {
+		return nil, entities.ErrPromoCodeMinSpend
+	}
+	if p.MaxUses > 0 && p.UsedCount >= p.MaxUses {
+		return nil, entities.ErrPromoCodeExhausted
+	}
+	if p.FirstBookingOnly {
+		n, err := u.bookingRepo.CountByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			return nil, entities.ErrPromoCodeFirstBooking
+		}
+	}
+	if p.MaxUsesPerUser > 0 {
+		n, err := u.repo.CountUserRedemptions(ctx, p.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if n >= p.MaxUsesPerUser {
+			return nil, entities.ErrPromoCodeUserLimit
+		}
+	}
+	return p, nil
+}
+
+func (u *promoCodeInteractor) Redeem(ctx context.Context, promoID, userID string) (string, error) {
+	red := &entities.PromoRedemption{PromoCodeID: promoID, UserID: userID}
+	if err := u.repo.Redeem(ctx, red); err != nil {
+		return "", err
+	}
+	return red.ID, nil
+}
+
+func (u *promoCodeInteractor) Release(ctx context.Context, redemptionID string) error {
+	return u.repo.Release(ctx, redemptionID)
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validatePromoCode(p *entities.PromoCode) error {
+	p.Code = normalizePromoCode(p.Code)
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+	switch p.DiscountType {
+	case entities.DiscountPercent:
+		if p.DiscountValue <= 0 || p.DiscountValue > 100 {
+			return errors.New("percent discount must be in (0, 100]")
+		}
+	case entities.DiscountFixed, entities.DiscountFreeHours:
+		if p.DiscountValue <= 0 {
+			return errors.New("discount value must be positive")
+		}
+	default:
+		return errors.New("unknown discount type")
+	}
+	if !p.ValidFrom.IsZero() && !p.ValidUntil.IsZero() && p.ValidUntil.Before(p.ValidFrom) {
+		return errors.New("valid_until must be after valid_from")
+	}
+	if p.MaxUses < 0 || p.MaxUsesPerUser < 0 || p.MinSpend < 0 {
+		return errors.New("limits must not be negative")
+	}
+	return nil
+}
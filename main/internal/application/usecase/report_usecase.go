@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"sort"
+	"time"
+)
+
+// ReportUseCase builds financial reports for clubs.
+type ReportUseCase interface {
+	Revenue(ctx context.Context, clubID string, from, to time.Time) (*entities.RevenueReport, error)
+	// Cash sums offline payments of a club, optionally of one staff member,
+	// for an end-of-shift cash count.
+	Cash(ctx context.Context, clubID, staffID string, from, to time.Time) (*entities.CashReport, error)
+}
+
+type reportInteractor struct {
+	bookingRepo repository.BookingRepository
+	ledgerRepo  repository.PaymentLedgerRepository
+	clubRepo    repository.ClubRepository
+	userUC      UserUseCase
+}
+
+// NewReportUseCase constructs a new ReportUseCase with the given repositories.
+func NewReportUseCase(
+	bRepo repository.BookingRepository,
+	lRepo repository.PaymentLedgerRepository,
+	cRepo repository.ClubRepository,
+	userUC UserUseCase,
+) ReportUseCase {
+	return &reportInteractor{bookingRepo: bRepo, ledgerRepo: lRepo, clubRepo: cRepo, userUC: userUC}
+}
+
+// clubLocation returns the time zone report periods are shown in.
+func (u *reportInteractor) clubLocation(ctx context.Context, clubID string) (*time.Location, error) {
+	club, err := u.clubRepo.FindByID(ctx, clubID)
+	if err != nil {
+		return nil, ErrInvalidClub
+	}
+	return club.TZ(), nil
+}
+
+// Revenue sums paid bookings using the tax rate stored on each booking, so
+// later changes to a club's tax profile do not rewrite past periods.
+func (u *reportInteractor) Revenue(ctx context.Context, clubID string, from, to time.Time) (*entities.RevenueReport, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidInterval
+	}
+	list, err := u.bookingRepo.FindByClub(ctx, clubID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := u.clubLocation(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	rep := &entities.RevenueReport{ClubID: clubID, Timezone: loc.String(), From: from.In(loc), To: to.In(loc)}
+	byRate := map[float64]*entities.TaxBreakdown{}
+	for _, b := range list {
+		if b.Status != entities.BookingConfirmed && b.Status != entities.BookingCompleted {
+			continue
+		}
+		net := b.TotalPrice - b.TaxAmount
+		rep.Bookings++
+		rep.Discounts += b.Discount
+		rep.Net += net
+		rep.Tax += b.TaxAmount
+		rep.Gross += b.TotalPrice
+
+		br, ok := byRate[b.TaxRate]
+		if !ok {
+			br = &entities.TaxBreakdown{Rate: b.TaxRate}
+			byRate[b.TaxRate] = br
+		}
+		br.Bookings++
+		br.Net += net
+		br.Tax += b.TaxAmount
+		br.Gross += b.TotalPrice
+	}
+	rep.Discounts = roundMoney(rep.Discounts)
+	rep.Net = roundMoney(rep.Net)
+	rep.Tax = roundMoney(rep.Tax)
+	rep.Gross = roundMoney(rep.Gross)
+	rep.ByTaxRate = make([]entities.TaxBreakdown, 0, len(byRate))
+	for _, br := range byRate {
+		br.Net = roundMoney(br.Net)
+		br.Tax = roundMoney(br.Tax)
+		br.Gross = roundMoney(br.Gross)
+		rep.ByTaxRate = append(rep.ByTaxRate, *br)
+	}
+	sort.Slice(rep.ByTaxRate, func(i, j int) bool { return rep.ByTaxRate[i].Rate < rep.ByTaxRate[j].Rate })
+	return rep, nil
+}
+
+func (u *reportInteractor) Cash(ctx context.Context, clubID, staffID string, from, to time.Time) (*entities.CashReport, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidInterval
+	}
+	list, err := u.ledgerRepo.FindByClub(ctx, clubID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := u.clubLocation(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	rep := &entities.CashReport{
+		ClubID:   clubID,
+		Timezone: loc.String(),
+		StaffID:  staffID,
+		From:     from.In(loc),
+		To:       to.In(loc),
+		ByMethod: []entities.CashMethodBreakdown{},
+		Entries:  []*entities.PaymentLedgerEntry{},
+	}
+	byMethod := map[string]*entities.CashMethodBreakdown{}
+	var total int64
+	for _, e := range list {
+		if !entities.IsOfflinePaymentMethod(e.Provider) {
+			continue
+		}
+		if staffID != "" && e.StaffID != staffID {
+			continue
+		}
+		m, ok := byMethod[e.Provider]
+		if !ok {
+			m = &entities.CashMethodBreakdown{Method: e.Provider}
+			byMethod[e.Provider] = m
+		}
+		if e.Type == entities.LedgerRefund {
+			rep.Refunds++
+			m.Refunds++
+		} else {
+			rep.Payments++
+			m.Payments++
+		}
+		m.Amount += fromMinorUnits(e.Amount)
+		total += e.Amount
+		rep.Entries = append(rep.Entries, e)
+	}
+	rep.Total = fromMinorUnits(total)
+	for _, m := range byMethod {
+		m.Amount = roundMoney(m.Amount)
+		rep.ByMethod = append(rep.ByMethod, *m)
+	}
+	sort.Slice(rep.ByMethod, func(i, j int) bool { return rep.ByMethod[i].Method < rep.ByMethod[j].Method })
+	sort.Slice(rep.Entries, func(i, j int) bool { return rep.Entries[i].CreatedAt.Before(rep.Entries[j].CreatedAt) })
+	staff := make([]string, 0, len(rep.Entries))
+	for _, e := range rep.Entries {
+		staff = append(staff, e.StaffID)
+	}
+	if rep.Names, err = u.userUC.Names(ctx, staff); err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+// maxPreferredClubs bounds the clubs a user can pin.
+const maxPreferredClubs = 10
+
+// UserUseCase defines business logic for user profiles.
+type UserUseCase interface {
+	// Ensure returns the profile of seed.ID, creating it from seed the first
+	// time the user is seen.
+	Ensure(ctx context.Context, seed *entities.User) (*entities.User, error)
+	Get(ctx context.Context, id string) (*entities.User, error)
+	Update(ctx context.Context, id string, patch *entities.UserPatch) (*entities.User, error)
+	// Names maps each of ids to the name shown for it; UIDs without a
+	// profile map to themselves.
+	Names(ctx context.Context, ids []string) (map[string]string, error)
+	// RequestVerification puts the user in the staff queue for an ID check.
+	RequestVerification(ctx context.Context, id string) (*entities.User, error)
+	// Verify records the outcome of staff checking the ID of user id:
+	// the verified birth date when approved, a note otherwise.
+	Verify(ctx context.Context, staffID, id string, r *entities.VerificationReview) (*entities.User, error)
+	// PendingVerifications lists users waiting for an ID check, oldest
+	// request first.
+	PendingVerifications(ctx context.Context) ([]*entities.User, error)
+	// SetRole changes the role user id signs in with; empty makes them a
+	// customer. Staff must be given the club they work at. It applies from
+	// the user's next token refresh.
+	SetRole(ctx context.Context, id, role, clubID string) error
+}
+
+type userInteractor struct {
+	repo     repository.UserRepository
+	clubRepo repository.ClubRepository
+	roles    repository.RoleStore
+}
+
+// NewUserUseCase constructs a new UserUseCase with the given repositories.
+func NewUserUseCase(
+	r repository.UserRepository,
+	clubRepo repository.ClubRepository,
+	roles repository.RoleStore,
+) UserUseCase {
+	return &userInteractor{repo: r, clubRepo: clubRepo, roles: roles}
+}
+
+func (u *userInteractor) Ensure(ctx context.Context, seed *entities.User) (*entities.User, error) {
+	user, err := u.repo.FindByID(ctx, seed.ID)
+	if !errors.Is(err, entities.ErrUserNotFound) {
+		return user, err
+	}
+	now := time.Now()
+	seed.Language = entities.DefaultLanguage
+	seed.PreferredClubs = []string{}
+	seed.CreatedAt = now
+	seed.UpdatedAt = now
+	return u.repo.Create(ctx, seed)
+}
+
+func (u *userInteractor) Get(ctx context.Context, id string) (*entities.User, error) {
+	return u.repo.FindByID(ctx, id)
+}
+
+func (u *userInteractor) Update(ctx context.Context, id string, patch *entities.UserPatch) (*entities.User, error) {
+	user, err := u.Ensure(ctx, &entities.User{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := patch.Apply(user, now); err != nil {
+		return nil, err
+	}
+	if patch.PreferredClubs != nil {
+		if len(user.PreferredClubs) > maxPreferredClubs {
+			return nil, fmt.Errorf("%w: at most %d preferred clubs", entities.ErrInvalidProfile, maxPreferredClubs)
+		}
+		for _, clubID := range user.PreferredClubs {
+			if _, err := u.clubRepo.FindByID(ctx, clubID); err != nil {
+				return nil, fmt.Errorf("%w: unknown club %s", entities.ErrInvalidProfile, clubID)
+			}
+		}
+	}
+	user.UpdatedAt = now
+	if err := u.repo.Update(ctx, user, append(patch.Fields(), "updated_at")...); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userInteractor) Names(ctx context.Context, ids []string) (map[string]string, error) {
+	seen := make(map[string]bool, len(ids))
+	var unique []string
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	users, err := u.repo.FindByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(unique))
+	for _, id := range unique {
+		names[id] = id
+		if user, ok := users[id]; ok {
+			names[id] = user.Name()
+		}
+	}
+	return names, nil
+}
+
+func (u *userInteractor) RequestVerification(ctx context.Context, id string) (*entities.User, error) {
+	user, err := u.Ensure(ctx, &entities.User{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if user.AgeVerification.Status == entities.AgeVerified || user.AgeVerification.Status == entities.AgePending {
+		return user, nil
+	}
+	now := time.Now()
+	user.AgeVerification = entities.AgeVerification{Status: entities.AgePending, RequestedAt: now}
+	user.UpdatedAt = now
+	if err := u.repo.Update(ctx, user, "age_verification", "updated_at"); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userInteractor) Verify(ctx context.Context, staffID, id string, r *entities.VerificationReview) (*entities.User, error) {
+	now := time.Now()
+	if err := r.Validate(now); err != nil {
+		return nil, err
+	}
+	user, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	v := entities.AgeVerification{
+		Status:      entities.AgeRejected,
+		RequestedAt: user.AgeVerification.RequestedAt,
+		ReviewedBy:  staffID,
+		ReviewedAt:  now,
+		Note:        r.Note,
+	}
+	if v.RequestedAt.IsZero() {
+		v.RequestedAt = now
+	}
+	if r.Approved {
+		v.Status = entities.AgeVerified
+		v.BirthDate = r.BirthDate
+	}
+	user.AgeVerification = v
+	user.UpdatedAt = now
+	if err := u.repo.Update(ctx, user, "age_verification", "updated_at"); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userInteractor) PendingVerifications(ctx context.Context) ([]*entities.User, error) {
+	return u.repo.FindByVerificationStatus(ctx, entities.AgePending)
+}
+
+func (u *userInteractor) SetRole(ctx context.Context, id, role, clubID string) error {
+	if !entities.IsValidRole(role) {
+		return entities.ErrInvalidRole
+	}
+	// only staff are tied to a club
+	if role != entities.RoleStaff {
+		clubID = ""
+	} else if clubID == "" {
+		return entities.ErrStaffClub
+	}
+	return u.roles.SetRole(ctx, id, role, clubID)
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"main/internal/infrastructure/stripeclient"
+)
+
+// Metadata attached to PaymentIntents so the webhook knows what they pay for.
+const (
+	PaymentPurposeKey     = "purpose"
+	PaymentPurposeTopUp   = "wallet_topup"
+	PaymentPurposeBooking = "booking"
+	PaymentUserKey        = "user_id"
+	PaymentBookingKey     = "booking_id"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// WalletUseCase defines business logic for user wallets.
+type WalletUseCase interface {
+	Get(ctx context.Context, userID string) (*entities.Wallet, error)
+	GetTransactions(ctx context.Context, userID string) ([]*entities.WalletTransaction, error)
+	// CreateTopUpIntent creates a PaymentIntent that credits the wallet once
+	// it succeeds. amount is in the currency's minor units.
+	CreateTopUpIntent(ctx context.Context, userID string, amount int64, currency string) (string, error)
+	TopUp(ctx context.Context, userID string, amount float64, paymentIntentID string) error
+	Charge(ctx context.Context, userID string, amount float64, bookingID string) error
+	Refund(ctx context.Context, userID string, amount float64, bookingID string) error
+	Adjust(ctx context.Context, userID string, amount float64, actorUID, note string) error
+}
+
+type walletInteractor struct {
+	repo     repository.WalletRepository
+	ledgerUC PaymentLedgerUseCase
+}
+
+// NewWalletUseCase constructs a new WalletUseCase with the given repository.
+func NewWalletUseCase(r repository.WalletRepository, ledgerUC PaymentLedgerUseCase) WalletUseCase {
+	return &walletInteractor{repo: r, ledgerUC: ledgerUC}
+}
+
+func (u *walletInteractor) Get(ctx context.Context, userID string) (*entities.Wallet, error) {
+	return u.repo.FindByUser(ctx, userID)
+}
+
+func (u *walletInteractor) GetTransactions(ctx context.Context, userID string) ([]*entities.WalletTransaction, error) {
+	return u.repo.FindTransactions(ctx, userID)
+}
+
+func (u *walletInteractor) CreateTopUpIntent(ctx context.Context, userID string, amount int64, currency string) (string, error) {
+	if amount <= 0 {
+		return "", ErrInvalidAmount
+	}
+	pi, err := stripeclient.CreatePaymentIntent(amount, currency, map[string]string{
+		PaymentPurposeKey: PaymentPurposeTopUp,
+		PaymentUserKey:    userID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := u.ledgerUC.RecordIntent(ctx, pi.ID, pi.Amount, string(pi.Currency), pi.Metadata); err != nil {
+		return "", err
+	}
+	return pi.ClientSecret, nil
+}
+
+// TopUp is keyed by the PaymentIntent so webhook retries credit only once.
+func (u *walletInteractor) TopUp(ctx context.Context, userID string, amount float64, paymentIntentID string) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	return u.repo.Apply(ctx, "topup_"+paymentIntentID, &entities.WalletTransaction{
+		UserID:          userID,
+		Type:            entities.WalletTopUp,
+		Amount:          amount,
+		PaymentIntentID: paymentIntentID,
+	})
+}
+
+func (u *walletInteractor) Charge(ctx context.Context, userID string, amount float64, bookingID string) error {
+	if amount < 0 {
+		return ErrInvalidAmount
+	}
+	return u.repo.Apply(ctx, "charge_"+bookingID, &entities.WalletTransaction{
+		UserID:    userID,
+		Type:      entities.WalletCharge,
+		Amount:    -amount,
+		BookingID: bookingID,
+	})
+}
+
+func (u *walletInteractor) Refund(ctx context.Context, userID string, amount float64, bookingID string) error {
+	if amount < 0 {
+		return ErrInvalidAmount
+	}
+	return u.repo.Apply(ctx, "refund_"+bookingID, &entities.WalletTransaction{
+		UserID:    userID,
+		Type:      entities.WalletRefund,
+		Amount:    amount,
+		BookingID: bookingID,
+	})
+}
+
+func (u *walletInteractor) Adjust(ctx context.Context, userID string, amount float64, actorUID, note string) error {
+	if amount == 0 {
+		return ErrInvalidAmount
+	}
+	if note == "" {
+		return errors.New("note is required for manual adjustments")
+	}
+	return u.repo.Apply(ctx, "", &entities.WalletTransaction{
+		UserID:   userID,
+		Type:     entities.WalletAdjustment,
+		Amount:   amount,
+		ActorUID: actorUID,
+		Note:     note,
+	})
+}
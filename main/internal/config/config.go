@@ -18,12 +18,29 @@ type FirebaseConfig struct {
 type StripeConfig struct {
 	SecretKey     string `mapstructure:"secret_key"`
 	WebhookSecret string `mapstructure:"webhook_secret"`
+	// Checkout redirect targets; {BOOKING_ID} is replaced with the booking ID
+	// and {CHECKOUT_SESSION_ID} is filled in by Stripe.
+	CheckoutSuccessURL string `mapstructure:"checkout_success_url"`
+	CheckoutCancelURL  string `mapstructure:"checkout_cancel_url"`
+}
+
+type AuditConfig struct {
+	// RetentionDays is how long audit entries are kept; 0 keeps them forever.
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
+type ClubsConfig struct {
+	// PurgeAfterDays is how long deleted clubs can be restored before they
+	// are purged; 0 keeps them forever.
+	PurgeAfterDays int `mapstructure:"purge_after_days"`
 }
 
 type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	Firebase FirebaseConfig `mapstructure:"firebase"`
 	Stripe   StripeConfig   `mapstructure:"stripeclient"`
+	Audit    AuditConfig    `mapstructure:"audit"`
+	Clubs    ClubsConfig    `mapstructure:"clubs"`
 }
 
 var Cfg Config
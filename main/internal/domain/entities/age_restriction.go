@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Age verification statuses.
+const (
+	AgeUnverified = ""
+	AgePending    = "pending"
+	AgeVerified   = "verified"
+	AgeRejected   = "rejected"
+)
+
+var (
+	ErrInvalidAgeRestriction = errors.New("invalid age restriction")
+	ErrAgeNotVerified        = errors.New("verified age is required during the club's age-restricted hours; show an ID at the front desk")
+	ErrUnderage              = errors.New("guests below the minimum age are not admitted during the club's age-restricted hours")
+	ErrInvalidVerification   = errors.New("invalid age verification")
+)
+
+// AgeRestriction keeps guests younger than MinAge out of a club during
+// Windows, e.g. 22:00 to 06:00 every day. Windows use the wall-clock rules
+// of DayHours.
+type AgeRestriction struct {
+	MinAge  int        `firestore:"min_age"  json:"min_age"`
+	Windows []DayHours `firestore:"windows"  json:"windows"`
+}
+
+// Validate checks the age and the wall-clock values of r.
+func (r *AgeRestriction) Validate() error {
+	if r.MinAge <= 0 || r.MinAge > 99 {
+		return fmt.Errorf("%w: min_age must be between 1 and 99", ErrInvalidAgeRestriction)
+	}
+	for _, w := range r.Windows {
+		if w.Day < time.Sunday || w.Day > time.Saturday {
+			return fmt.Errorf("%w: day must be 0 to 6", ErrInvalidAgeRestriction)
+		}
+		if _, err := parseClock(w.Open); err != nil {
+			return fmt.Errorf("%w: open must be HH:MM", ErrInvalidAgeRestriction)
+		}
+		if _, err := parseClock(w.Close); err != nil {
+			return fmt.Errorf("%w: close must be HH:MM", ErrInvalidAgeRestriction)
+		}
+	}
+	return nil
+}
+
+// Overlaps reports whether [start, end) touches a restricted window. Times
+// are read in their own location, so pass them in the club's time zone.
+// A nil restriction never applies.
+func (r *AgeRestriction) Overlaps(start, end time.Time) bool {
+	if r == nil {
+		return false
+	}
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()).AddDate(0, 0, -1)
+	for !day.After(end) {
+		for _, w := range r.Windows {
+			if w.Day != day.Weekday() {
+				continue
+			}
+			s := clockSpan(day, w.Open, w.Close)
+			if s.from.Before(end) && start.Before(s.to) {
+				return true
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return false
+}
+
+// Admit returns nil if u may be in the club over [start, end) and otherwise
+// ErrAgeNotVerified or ErrUnderage. A nil user has no verified age.
+func (r *AgeRestriction) Admit(u *User, start, end time.Time) error {
+	if !r.Overlaps(start, end) {
+		return nil
+	}
+	if u == nil || u.AgeVerification.Status != AgeVerified {
+		return ErrAgeNotVerified
+	}
+	if age, ok := AgeAt(u.AgeVerification.BirthDate, start); !ok || age < r.MinAge {
+		return fmt.Errorf("%w: must be %d or older", ErrUnderage, r.MinAge)
+	}
+	return nil
+}
+
+// AgeAt returns the age in whole years on t of someone born on birthDate
+// (YYYY-MM-DD).
+func AgeAt(birthDate string, t time.Time) (int, bool) {
+	b, err := time.Parse("2006-01-02", birthDate)
+	if err != nil {
+		return 0, false
+	}
+	age := t.Year() - b.Year()
+	if t.Month() < b.Month() || (t.Month() == b.Month() && t.Day() < b.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// AgeVerification records staff checking a user's ID. BirthDate is the
+// verified date, independent of the self-declared User.BirthDate.
+type AgeVerification struct {
+	Status      string    `firestore:"status"        json:"status"`
+	BirthDate   string    `firestore:"birth_date"    json:"birth_date,omitempty"`
+	RequestedAt time.Time `firestore:"requested_at"  json:"requested_at,omitempty"`
+	ReviewedBy  string    `firestore:"reviewed_by"   json:"reviewed_by,omitempty"`
+	ReviewedAt  time.Time `firestore:"reviewed_at"   json:"reviewed_at,omitempty"`
+	Note        string    `firestore:"note"          json:"note,omitempty"`
+}
+
+// VerificationReview is staff's decision on an age verification. BirthDate
+// (YYYY-MM-DD, from the ID) is required to approve, Note to reject.
+type VerificationReview struct {
+	Approved  bool   `json:"approved"`
+	BirthDate string `json:"birth_date"`
+	Note      string `json:"note"`
+}
+
+// Validate checks r against the date now.
+func (r *VerificationReview) Validate(now time.Time) error {
+	if !r.Approved {
+		if r.Note == "" {
+			return fmt.Errorf("%w: a rejection needs a note", ErrInvalidVerification)
+		}
+		return nil
+	}
+	b, err := time.Parse("2006-01-02", r.BirthDate)
+	if err != nil {
+		return fmt.Errorf("%w: birth_date must be YYYY-MM-DD", ErrInvalidVerification)
+	}
+	if !b.Before(now) {
+		return fmt.Errorf("%w: birth_date is in the future", ErrInvalidVerification)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// AgentOfflineAfter is how long a PC agent may stay silent before the
+// computer is shown offline.
+const AgentOfflineAfter = 2 * time.Minute
+
+// Alert kinds.
+const (
+	AlertPCOffline = "pc_offline"
+)
+
+var (
+	ErrAlertNotFound  = errors.New("alert not found")
+	ErrAlertOtherClub = errors.New("staff can only resolve alerts at their own club")
+)
+
+// AgentStatus is what the agent running on a PC last reported. Online is
+// cleared by a background sweep once LastSeen is older than
+// AgentOfflineAfter.
+type AgentStatus struct {
+	Online      bool      `firestore:"online"        json:"online"`
+	LastSeen    time.Time `firestore:"last_seen"     json:"last_seen"`
+	SessionUser string    `firestore:"session_user"  json:"session_user,omitempty"`
+	CPUTempC    float64   `firestore:"cpu_temp_c"    json:"cpu_temp_c"`
+	GPUTempC    float64   `firestore:"gpu_temp_c"    json:"gpu_temp_c"`
+	UptimeSec   int64     `firestore:"uptime_sec"    json:"uptime_sec"`
+}
+
+// Heartbeat is one report from a PC agent.
+type Heartbeat struct {
+	SessionUser string  `json:"session_user"`
+	CPUTempC    float64 `json:"cpu_temp_c"`
+	GPUTempC    float64 `json:"gpu_temp_c"`
+	UptimeSec   int64   `json:"uptime_sec"`
+}
+
+// Alert asks club staff to look at a computer, e.g. one that went dark
+// during a booking. It stays open until resolved.
+type Alert struct {
+	ID         string    `firestore:"id"           json:"id"`
+	ClubID     string    `firestore:"club_id"      json:"club_id"`
+	ComputerID string    `firestore:"computer_id"  json:"computer_id"`
+	PCNumber   int       `firestore:"pc_number"    json:"pc_number"`
+	BookingID  string    `firestore:"booking_id"   json:"booking_id,omitempty"`
+	Kind       string    `firestore:"kind"         json:"kind"`
+	Message    string    `firestore:"message"      json:"message"`
+	Open       bool      `firestore:"open"         json:"open"`
+	CreatedAt  time.Time `firestore:"created_at"   json:"created_at"`
+	ResolvedAt time.Time `firestore:"resolved_at"  json:"resolved_at,omitempty"`
+	ResolvedBy string    `firestore:"resolved_by"  json:"resolved_by,omitempty"`
+}
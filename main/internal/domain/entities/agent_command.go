@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// Agent command kinds.
+const (
+	CommandUnlock = "unlock"
+	CommandWarn   = "warn"
+	CommandLock   = "lock"
+	CommandReboot = "reboot"
+)
+
+// Agent command statuses. A command is pending until an agent fetches it,
+// then delivered until acknowledged; unacknowledged commands are delivered
+// again after CommandAckTimeout, up to MaxAttempts times.
+const (
+	CommandPending   = "pending"
+	CommandDelivered = "delivered"
+	CommandAcked     = "acked"
+	CommandFailed    = "failed"
+	CommandExpired   = "expired"
+	CommandCancelled = "cancelled"
+)
+
+const (
+	// CommandAckTimeout is how long a delivered command waits for its
+	// acknowledgement before it is delivered again.
+	CommandAckTimeout = 30 * time.Second
+	// CommandTTL is how long after NotBefore a command may still run.
+	CommandTTL = 10 * time.Minute
+	// CommandMaxAttempts is the default number of deliveries.
+	CommandMaxAttempts = 5
+	// SessionWarning is how long before the end of a session the player is
+	// warned.
+	SessionWarning = 5 * time.Minute
+)
+
+var (
+	ErrInvalidCommand  = errors.New("unknown command kind")
+	ErrCommandNotFound = errors.New("command not found")
+	ErrCommandClosed   = errors.New("command is no longer awaiting acknowledgement")
+)
+
+// IsValidCommand reports whether kind is a known agent command.
+func IsValidCommand(kind string) bool {
+	switch kind {
+	case CommandUnlock, CommandWarn, CommandLock, CommandReboot:
+		return true
+	}
+	return false
+}
+
+// AgentCommand is queued for the agent of one computer. Agents may receive
+// a command more than once and should act on each ID once.
+type AgentCommand struct {
+	ID          string    `firestore:"id"            json:"id"`
+	ComputerID  string    `firestore:"computer_id"   json:"computer_id"`
+	ClubID      string    `firestore:"club_id"       json:"club_id"`
+	BookingID   string    `firestore:"booking_id"    json:"booking_id,omitempty"`
+	Kind        string    `firestore:"kind"          json:"kind"`
+	Message     string    `firestore:"message"       json:"message,omitempty"`
+	Status      string    `firestore:"status"        json:"status"`
+	NotBefore   time.Time `firestore:"not_before"    json:"not_before"`
+	ExpiresAt   time.Time `firestore:"expires_at"    json:"expires_at"`
+	Attempts    int       `firestore:"attempts"      json:"attempts"`
+	MaxAttempts int       `firestore:"max_attempts"  json:"max_attempts"`
+	DeliveredAt time.Time `firestore:"delivered_at"  json:"delivered_at,omitempty"`
+	AckedAt     time.Time `firestore:"acked_at"      json:"acked_at,omitempty"`
+	Error       string    `firestore:"error"         json:"error,omitempty"`
+	CreatedBy   string    `firestore:"created_by"    json:"created_by"`
+	CreatedAt   time.Time `firestore:"created_at"    json:"created_at"`
+}
+
+// Open reports whether the command may still be delivered or acknowledged.
+func (c *AgentCommand) Open() bool {
+	return c.Status == CommandPending || c.Status == CommandDelivered
+}
+
+// Due reports whether the command should be handed to the agent at now.
+func (c *AgentCommand) Due(now time.Time) bool {
+	switch {
+	case now.Before(c.NotBefore) || !now.Before(c.ExpiresAt):
+		return false
+	case c.Status == CommandPending:
+		return true
+	case c.Status == CommandDelivered:
+		return c.Attempts < c.MaxAttempts && !now.Before(c.DeliveredAt.Add(CommandAckTimeout))
+	}
+	return false
+}
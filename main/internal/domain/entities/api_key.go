@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// API key scopes. ScopeAgent keys belong to one computer and drive its
+// agent; ScopeFrontDesk keys let a kiosk check in and settle bookings.
+const (
+	ScopeAgent     = "agent"
+	ScopeFrontDesk = "front_desk"
+)
+
+// Principal kinds.
+const (
+	PrincipalUser   = "user"
+	PrincipalAPIKey = "api_key"
+)
+
+// APIKeyTag starts every API key so it can be told from a Firebase token.
+const APIKeyTag = "ck_"
+
+// APIKeyRotationGrace is how long a rotated key keeps working so clients
+// can switch to its replacement.
+const APIKeyRotationGrace = 24 * time.Hour
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidScope   = errors.New("invalid api key scope")
+	ErrAPIKeyRevoked  = errors.New("api key is revoked")
+)
+
+// IsValidScope reports whether s is a known API key scope.
+func IsValidScope(s string) bool {
+	return s == ScopeAgent || s == ScopeFrontDesk
+}
+
+// APIKey authenticates a machine client of one club. The secret is shown
+// once; Prefix identifies the key in lists and logs and Hash verifies it.
+type APIKey struct {
+	ID         string    `firestore:"id"            json:"id"`
+	ClubID     string    `firestore:"club_id"       json:"club_id"`
+	Name       string    `firestore:"name"          json:"name"`
+	Prefix     string    `firestore:"prefix"        json:"prefix"`
+	Hash       string    `firestore:"hash"          json:"-"`
+	Scopes     []string  `firestore:"scopes"        json:"scopes"`
+	ComputerID string    `firestore:"computer_id"   json:"computer_id,omitempty"`
+	CreatedBy  string    `firestore:"created_by"    json:"created_by"`
+	CreatedAt  time.Time `firestore:"created_at"    json:"created_at"`
+	LastUsedAt time.Time `firestore:"last_used_at"  json:"last_used_at,omitempty"`
+	ExpiresAt  time.Time `firestore:"expires_at"    json:"expires_at,omitempty"`
+	RevokedAt  time.Time `firestore:"revoked_at"    json:"revoked_at,omitempty"`
+	ReplacedBy string    `firestore:"replaced_by"   json:"replaced_by,omitempty"`
+}
+
+// Active reports whether the key can be used at now.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt.IsZero() && (k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt))
+}
+
+// HasScope reports whether the key grants scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal is the authenticated caller of a request: a Firebase user with
+// a role, or a club API key with scopes. ClubID is the key's club, or the
+// club a staff member works at.
+type Principal struct {
+	Kind       string   `json:"kind"`
+	UID        string   `json:"uid"`
+	Role       string   `json:"role,omitempty"`
+	KeyID      string   `json:"key_id,omitempty"`
+	ClubID     string   `json:"club_id,omitempty"`
+	ComputerID string   `json:"computer_id,omitempty"`
+	Scopes     []string `json:"scopes,omitempty"`
+}
+
+// HasScope reports whether an API key principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ClubBound reports whether the principal only reaches its own club, as
+// keys and staff do.
+func (p *Principal) ClubBound() bool {
+	return p.Kind == PrincipalAPIKey || p.Role == RoleStaff
+}
+
+// CanAccessClub reports whether the principal may act on clubID. Other
+// users than staff are limited by role elsewhere.
+func (p *Principal) CanAccessClub(clubID string) bool {
+	return !p.ClubBound() || (p.ClubID != "" && p.ClubID == clubID)
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Audit actions.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// Audited entity types.
+const (
+	AuditClub     = "club"
+	AuditComputer = "computer"
+	AuditBooking  = "booking"
+	AuditPayment  = "payment"
+	AuditWallet   = "wallet_transaction"
+	AuditRole     = "role"
+)
+
+// ActorSystem is the actor kind of changes made outside a request, e.g. by
+// background jobs.
+const ActorSystem = "system"
+
+var ErrInvalidAuditFilter = errors.New("invalid audit filter")
+
+// Actor is who made a change and from which request. Kind is a Principal
+// kind or ActorSystem.
+type Actor struct {
+	Kind      string `firestore:"kind"        json:"kind"`
+	UID       string `firestore:"uid"         json:"uid,omitempty"`
+	KeyID     string `firestore:"key_id"      json:"key_id,omitempty"`
+	Role      string `firestore:"role"        json:"role,omitempty"`
+	IP        string `firestore:"ip"          json:"ip,omitempty"`
+	RequestID string `firestore:"request_id"  json:"request_id,omitempty"`
+	Route     string `firestore:"route"       json:"route,omitempty"`
+}
+
+type actorKey struct{}
+
+// WithActor returns ctx carrying a.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom returns the actor carried by ctx, or the system actor.
+func ActorFrom(ctx context.Context) Actor {
+	if a, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return a
+	}
+	return Actor{Kind: ActorSystem}
+}
+
+// AuditEntry is one append-only record of a change. Changes maps each
+// changed field, by its stored name, to its old and new value; a created
+// entity has only new values and a deleted one only old values.
+type AuditEntry struct {
+	ID         string            `firestore:"id"           json:"id"`
+	At         time.Time         `firestore:"at"           json:"at"`
+	Actor      Actor             `firestore:"actor"        json:"actor"`
+	Action     string            `firestore:"action"       json:"action"`
+	EntityType string            `firestore:"entity_type"  json:"entity_type"`
+	EntityID   string            `firestore:"entity_id"    json:"entity_id"`
+	Changes    map[string]Change `firestore:"changes"      json:"changes"`
+}
+
+// Change is the old and new value of one field.
+type Change struct {
+	Before interface{} `firestore:"before"  json:"before"`
+	After  interface{} `firestore:"after"   json:"after"`
+}
+
+// AuditFilter narrows an audit log listing; zero values mean no constraint.
+// Entries come newest first.
+type AuditFilter struct {
+	ActorUID   string
+	KeyID      string
+	Action     string
+	EntityType string
+	EntityID   string
+	From       time.Time
+	To         time.Time
+	Cursor     string
+	Limit      int
+}
+
+// AuditPage is one page of audit entries.
+type AuditPage struct {
+	Items      []*AuditEntry `json:"items"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
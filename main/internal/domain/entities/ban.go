@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Ban sources.
+const (
+	BanSourceStaff   = "staff"
+	BanSourceNoShows = "no_shows"
+)
+
+// BannedBySystem is recorded as BannedBy on automatic bans.
+const BannedBySystem = "system"
+
+var (
+	ErrUserBanned        = errors.New("user is banned")
+	ErrBanNotFound       = errors.New("ban not found")
+	ErrInvalidBan        = errors.New("a ban needs a user, a reason and an expiry in the future or none")
+	ErrGlobalBanRequired = errors.New("only admins can manage platform-wide bans")
+	ErrBanOtherClub      = errors.New("staff can only manage bans at their own club")
+	ErrInvalidNoShowRule = errors.New("no-show policy needs positive max_no_shows, window_days and ban_days")
+)
+
+// Ban keeps a user from booking at ClubID, or at every club when ClubID is
+// empty. A zero ExpiresAt lasts until the ban is lifted. Bans from the
+// no-show policy are soft: they expire on their own and staff can lift
+// them like any other.
+type Ban struct {
+	ID        string    `firestore:"id"          json:"id"`
+	UserID    string    `firestore:"user_id"     json:"user_id"`
+	ClubID    string    `firestore:"club_id"     json:"club_id,omitempty"`
+	Reason    string    `firestore:"reason"      json:"reason"`
+	Source    string    `firestore:"source"      json:"source"`
+	BannedBy  string    `firestore:"banned_by"   json:"banned_by"`
+	CreatedAt time.Time `firestore:"created_at"  json:"created_at"`
+	ExpiresAt time.Time `firestore:"expires_at"  json:"expires_at,omitempty"`
+	LiftedBy  string    `firestore:"lifted_by"   json:"lifted_by,omitempty"`
+	LiftedAt  time.Time `firestore:"lifted_at"   json:"lifted_at,omitempty"`
+}
+
+// Global reports whether the ban applies at every club.
+func (b *Ban) Global() bool {
+	return b.ClubID == ""
+}
+
+// ActiveAt reports whether the ban is in force at t.
+func (b *Ban) ActiveAt(t time.Time) bool {
+	return b.LiftedAt.IsZero() && (b.ExpiresAt.IsZero() || t.Before(b.ExpiresAt))
+}
+
+// Covers reports whether the ban applies at clubID.
+func (b *Ban) Covers(clubID string) bool {
+	return b.Global() || b.ClubID == clubID
+}
+
+// Err describes the ban to the banned user; it wraps ErrUserBanned.
+func (b *Ban) Err() error {
+	where := "at this club"
+	if b.Global() {
+		where = "on the platform"
+	}
+	until := "until lifted"
+	if !b.ExpiresAt.IsZero() {
+		until = "until " + b.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return fmt.Errorf("%w %s %s: %s", ErrUserBanned, where, until, b.Reason)
+}
+
+// NoShowPolicy bans a user from a club for BanDays once they have missed
+// MaxNoShows bookings there within the last WindowDays. A booking is a
+// no-show when it ends without a check-in.
+type NoShowPolicy struct {
+	MaxNoShows int `firestore:"max_no_shows"  json:"max_no_shows"`
+	WindowDays int `firestore:"window_days"   json:"window_days"`
+	BanDays    int `firestore:"ban_days"      json:"ban_days"`
+}
+
+// Validate checks that every limit of p is set and sane.
+func (p *NoShowPolicy) Validate() error {
+	if p.MaxNoShows <= 0 || p.WindowDays <= 0 || p.BanDays <= 0 || p.WindowDays > 365 || p.BanDays > 365 {
+		return ErrInvalidNoShowRule
+	}
+	return nil
+}
@@ -1,16 +1,113 @@
 package entities
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// Booking statuses.
+const (
+	BookingActive    = "active"
+	BookingConfirmed = "confirmed"
+	BookingCompleted = "completed"
+	BookingCancelled = "cancelled"
+	BookingRefunded  = "refunded"
+)
+
+// MaxBookingDuration is the longest interval a single booking can cover.
+// Listings of bookings overlapping a window rely on it to bound how early
+// such a booking can have started.
+const MaxBookingDuration = 24 * time.Hour
+
+// Payment methods recorded on a paid Booking.
+const (
+	PaymentMethodWallet = "wallet"
+	PaymentMethodCard   = "card"
+
+	// Offline methods recorded by staff at the front desk.
+	PaymentMethodCash     = "cash"
+	PaymentMethodTerminal = "terminal"
+	PaymentMethodKaspi    = "kaspi"
+)
+
+// IsOfflinePaymentMethod reports whether method is taken at the front desk.
+func IsOfflinePaymentMethod(method string) bool {
+	switch method {
+	case PaymentMethodCash, PaymentMethodTerminal, PaymentMethodKaspi:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrBookingNotOwned   = errors.New("booking belongs to another user")
+	ErrBookingNotPayable = errors.New("booking is not awaiting payment")
+	ErrBookingTransition = errors.New("booking cannot move to the requested status")
+	ErrPaymentAmount     = errors.New("paid amount does not match booking total")
+	ErrPaymentMethod     = errors.New("unsupported payment method")
+	ErrPaymentReference  = errors.New("payment reference is required")
+	ErrCheckInWindow     = errors.New("booking can only be checked in shortly before or during its interval")
+	ErrBookingStarted    = errors.New("booking can no longer be cancelled once it has started")
+	ErrPaymentInProgress = errors.New("a payment for this booking is already being processed")
+	ErrRefundAtDesk      = errors.New("bookings paid at the front desk are cancelled and refunded there")
+	ErrBookingTooLong    = errors.New("a booking can last at most 24 hours")
+)
 
 // Booking is the domain entity representing a reservation.
 type Booking struct {
-	ID         string    `firestore:"id"           json:"id"`
-	ClubID     string    `firestore:"club_id"      json:"club_id"`
-	UserID     string    `firestore:"user_id"      json:"user_id"`
-	PCNumber   int       `firestore:"pc_number"    json:"pc_number"`
-	StartTime  time.Time `firestore:"start_time"   json:"start_time"`
-	EndTime    time.Time `firestore:"end_time"     json:"end_time"`
-	TotalPrice float64   `firestore:"total_price"  json:"total_price"`
-	Status     string    `firestore:"status"       json:"status"`
-	CreatedAt  time.Time `firestore:"created_at"   json:"created_at"`
+	ID            string     `firestore:"id"              json:"id"`
+	ClubID        string     `firestore:"club_id"         json:"club_id"`
+	UserID        string     `firestore:"user_id"         json:"user_id"`
+	UserName      string     `firestore:"-"               json:"user_name,omitempty"`
+	PCNumber      int        `firestore:"pc_number"       json:"pc_number"`
+	StartTime     time.Time  `firestore:"start_time"      json:"start_time"`
+	EndTime       time.Time  `firestore:"end_time"        json:"end_time"`
+	Timezone      string     `firestore:"timezone"        json:"timezone"`
+	StartLocal    string     `firestore:"-"               json:"start_time_local,omitempty"`
+	EndLocal      string     `firestore:"-"               json:"end_time_local,omitempty"`
+	Subtotal      float64    `firestore:"subtotal"        json:"subtotal"`
+	Discount      float64    `firestore:"discount"        json:"discount"`
+	TaxRate       float64    `firestore:"tax_rate"        json:"tax_rate"`
+	TaxInclusive  bool       `firestore:"tax_inclusive"   json:"tax_inclusive"`
+	TaxAmount     float64    `firestore:"tax_amount"      json:"tax_amount"`
+	TotalPrice    float64    `firestore:"total_price"     json:"total_price"`
+	PromoCode     string     `firestore:"promo_code"      json:"promo_code,omitempty"`
+	RedemptionID  string     `firestore:"redemption_id"   json:"redemption_id,omitempty"`
+	LineItems     []LineItem `firestore:"line_items"      json:"line_items"`
+	MembershipID  string     `firestore:"membership_id"   json:"membership_id,omitempty"`
+	CoveredHours  float64    `firestore:"covered_hours"   json:"covered_hours,omitempty"`
+	PointsUsed    int64      `firestore:"points_used"     json:"points_used,omitempty"`
+	PointsEarned  int64      `firestore:"points_earned"   json:"points_earned,omitempty"`
+	Status        string     `firestore:"status"          json:"status"`
+	PaymentMethod string     `firestore:"payment_method"  json:"payment_method,omitempty"`
+	PaymentRef    string     `firestore:"payment_ref"     json:"payment_ref,omitempty"`
+	IntentID      string     `firestore:"intent_id"       json:"intent_id,omitempty"`
+	CheckoutID    string     `firestore:"checkout_id"     json:"checkout_id,omitempty"`
+	PaidBy        string     `firestore:"paid_by"         json:"paid_by,omitempty"`
+	RelocatedFrom int        `firestore:"relocated_from"  json:"relocated_from,omitempty"`
+	Flag          string     `firestore:"flag"            json:"flag,omitempty"`
+	PaidAt        time.Time  `firestore:"paid_at"         json:"paid_at,omitempty"`
+	CheckedInAt   time.Time  `firestore:"checked_in_at"   json:"checked_in_at,omitempty"`
+	NoShow        bool       `firestore:"no_show"         json:"no_show,omitempty"`
+	CreatedAt     time.Time  `firestore:"created_at"      json:"created_at"`
+}
+
+// Holds reports whether the booking still reserves its computer.
+func (b *Booking) Holds() bool {
+	return b.Status == BookingActive || b.Status == BookingConfirmed
+}
+
+// Overlaps reports whether the booking's interval intersects [start, end).
+// A zero end means open-ended.
+func (b *Booking) Overlaps(start, end time.Time) bool {
+	return b.EndTime.After(start) && (end.IsZero() || b.StartTime.Before(end))
+}
+
+// Localize renders the booking interval in the club's time zone.
+func (b *Booking) Localize() {
+	loc := LoadTimezone(b.Timezone)
+	b.StartTime = b.StartTime.In(loc)
+	b.EndTime = b.EndTime.In(loc)
+	b.StartLocal = b.StartTime.Format(LocalLayout)
+	b.EndLocal = b.EndTime.Format(LocalLayout)
 }
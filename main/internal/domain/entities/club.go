@@ -1,10 +1,143 @@
 package entities
 
-// Club is the domain entity representing a computer club.
+import (
+	"errors"
+	"main/internal/domain/geo"
+	"time"
+)
+
+// DefaultTimezone applies to clubs created before timezones were recorded.
+const DefaultTimezone = "Asia/Almaty"
+
+// LocalLayout renders club-local wall-clock times in API responses.
+const LocalLayout = "2006-01-02T15:04:05"
+
+var (
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	ErrInvalidTaxRate  = errors.New("tax rate must be between 0 and 100 percent")
+	ErrClubNotFound    = errors.New("club not found")
+	ErrClubHasBookings = errors.New("club has upcoming paid bookings; cancel them before deleting it")
+)
+
+// Club is the domain entity representing a computer club. CategoryPrices
+// overrides PricePerHour per computer category; Timezone is an IANA name
+// such as Asia/Aqtau. OwnerID is the UID of the owner who manages the
+// club. DeletedAt is set on clubs in the archive.
 type Club struct {
-	ID           string  `firestore:"id"             json:"id"`
-	Name         string  `firestore:"name"           json:"name"`
-	Address      string  `firestore:"address"        json:"address"`
-	PricePerHour float64 `firestore:"price_per_hour" json:"price_per_hour"`
-	AvailablePCs int     `firestore:"available_pcs"  json:"available_pcs"`
+	ID             string             `firestore:"id"               json:"id"`
+	Name           string             `firestore:"name"             json:"name"`
+	OwnerID        string             `firestore:"owner_id"         json:"owner_id"`
+	Address        string             `firestore:"address"          json:"address"`
+	City           string             `firestore:"city"             json:"city"`
+	Location       *GeoLocation       `firestore:"location"         json:"location,omitempty"`
+	Amenities      []string           `firestore:"amenities"        json:"amenities"`
+	Rating         float64            `firestore:"rating"           json:"rating"`
+	PricePerHour   float64            `firestore:"price_per_hour"   json:"price_per_hour"`
+	CategoryPrices map[string]float64 `firestore:"category_prices"  json:"category_prices,omitempty"`
+	AvailablePCs   int                `firestore:"available_pcs"    json:"available_pcs"`
+	MaxGPUClass    int                `firestore:"max_gpu_class"    json:"max_gpu_class"`
+	Hours          *OpeningHours      `firestore:"hours"            json:"hours,omitempty"`
+	AgeRestriction *AgeRestriction    `firestore:"age_restriction"  json:"age_restriction,omitempty"`
+	NoShowPolicy   *NoShowPolicy      `firestore:"no_show_policy"   json:"no_show_policy,omitempty"`
+	OpenNow        bool               `firestore:"-"                json:"open_now"`
+	Legal          LegalDetails       `firestore:"legal"            json:"legal"`
+	Tax            TaxProfile         `firestore:"tax"              json:"tax"`
+	Timezone       string             `firestore:"timezone"         json:"timezone"`
+	LocalTime      string             `firestore:"-"                json:"local_time,omitempty"`
+	DeletedAt      time.Time          `firestore:"deleted_at"       json:"deleted_at,omitempty"`
+}
+
+// ClubDeletion reports a club deletion and the bookings it cancelled.
+type ClubDeletion struct {
+	Club      *Club      `json:"club"`
+	Cancelled []*Booking `json:"cancelled"`
+}
+
+// HourlyRate returns the price per hour of a computer in category.
+func (c *Club) HourlyRate(category string) float64 {
+	if p, ok := c.CategoryPrices[category]; ok && p > 0 {
+		return p
+	}
+	return c.PricePerHour
+}
+
+// TZ returns the club's time zone. Schedules, pricing windows and report
+// days are evaluated in it.
+func (c *Club) TZ() *time.Location {
+	return LoadTimezone(c.Timezone)
+}
+
+// Localize fills the fields derived from the current time at the club.
+func (c *Club) Localize(now time.Time) {
+	local := now.In(c.TZ())
+	c.OpenNow = c.Hours.IsOpenAt(local)
+	c.LocalTime = local.Format(LocalLayout)
+}
+
+// LoadTimezone returns the named location, or DefaultTimezone for an empty
+// or unknown name.
+func LoadTimezone(name string) *time.Location {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc, _ = time.LoadLocation(DefaultTimezone)
+	}
+	return loc
+}
+
+// GeoLocation places a club on the map. Geohash is derived from the
+// coordinates on save and indexed for nearby search.
+type GeoLocation struct {
+	Lat     float64 `firestore:"lat"      json:"lat"`
+	Lng     float64 `firestore:"lng"      json:"lng"`
+	Geohash string  `firestore:"geohash"  json:"geohash"`
+}
+
+// Point returns the coordinates of l.
+func (l GeoLocation) Point() geo.Point {
+	return geo.Point{Lat: l.Lat, Lng: l.Lng}
+}
+
+// ClubDistance is a club in search results; Distance is set only when the
+// search was around a point.
+type ClubDistance struct {
+	*Club
+	Distance float64 `json:"distance_m,omitempty"`
+}
+
+// LegalDetails identify the legal entity operating a club on receipts.
+type LegalDetails struct {
+	Name    string `firestore:"name"     json:"name"`
+	Address string `firestore:"address"  json:"address"`
+	BIN     string `firestore:"bin"      json:"bin"` // business identification number
+}
+
+// TaxProfile describes how VAT applies to a club's prices.
+type TaxProfile struct {
+	Rate      float64 `firestore:"rate"       json:"rate"`      // percent, e.g. 12
+	Inclusive bool    `firestore:"inclusive"  json:"inclusive"` // prices already include tax
+	Exempt    bool    `firestore:"exempt"     json:"exempt"`
+	TaxID     string  `firestore:"tax_id"     json:"tax_id"`
+}
+
+// Validate checks that Rate is a percentage.
+func (t TaxProfile) Validate() error {
+	if t.Rate < 0 || t.Rate > 100 {
+		return ErrInvalidTaxRate
+	}
+	return nil
+}
+
+// Split returns the tax contained in or added to amount and the gross total.
+func (t TaxProfile) Split(amount float64) (tax, total float64) {
+	if t.Exempt || t.Rate <= 0 {
+		return 0, amount
+	}
+	if t.Inclusive {
+		return amount * t.Rate / (100 + t.Rate), amount
+	}
+	tax = amount * t.Rate / 100
+	return tax, amount + tax
 }
@@ -1,10 +1,97 @@
 package entities
 
-// Computer — доменная сущность компьютера в клубе.
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Computer categories, i.e. the zone of the club a PC stands in.
+const (
+	CategoryStandard = "standard"
+	CategoryVIP      = "vip"
+	CategoryBootcamp = "bootcamp"
+	CategoryConsole  = "console"
+)
+
+var (
+	ErrInvalidCategory    = errors.New("unknown computer category")
+	ErrComputerNotFound   = errors.New("computer not found")
+	ErrInvalidMaintenance = errors.New("maintenance needs a reason and a future return time")
+	ErrNotInMaintenance   = errors.New("computer is not in maintenance")
+	ErrComputerInService  = errors.New("computer is in maintenance at the requested time")
+	ErrComputerBooked     = errors.New("computer has open bookings; its PC number cannot change")
+)
+
+// IsValidCategory reports whether c is a known computer category.
+func IsValidCategory(c string) bool {
+	switch c {
+	case CategoryStandard, CategoryVIP, CategoryBootcamp, CategoryConsole:
+		return true
+	}
+	return false
+}
+
+// Computer — доменная сущность компьютера в клубе. Agent is set once the
+// PC agent has reported. WithClub marks computers archived along with
+// their club, which come back when the club is restored.
 type Computer struct {
-	ID          string `firestore:"id"           json:"id"`
-	ClubID      string `firestore:"club_id"      json:"club_id"`
-	PCNumber    int    `firestore:"pc_number"    json:"pc_number"`
-	Description string `firestore:"description"  json:"description"`
-	IsAvailable bool   `firestore:"is_available" json:"is_available"`
+	ID          string        `firestore:"id"           json:"id"`
+	ClubID      string        `firestore:"club_id"      json:"club_id"`
+	PCNumber    int           `firestore:"pc_number"    json:"pc_number"`
+	Description string        `firestore:"description"  json:"description"`
+	Category    string        `firestore:"category"     json:"category"`
+	Specs       ComputerSpecs `firestore:"specs"        json:"specs"`
+	IsAvailable bool          `firestore:"is_available" json:"is_available"`
+	GPUClass    int           `firestore:"gpu_class"    json:"gpu_class"`
+	Maintenance *Maintenance  `firestore:"maintenance"  json:"maintenance,omitempty"`
+	Agent       *AgentStatus  `firestore:"agent"        json:"agent,omitempty"`
+	DeletedAt   time.Time     `firestore:"deleted_at"   json:"deleted_at,omitempty"`
+	WithClub    bool          `firestore:"with_club"    json:"-"`
+}
+
+// Maintenance takes a computer out of service.
+type Maintenance struct {
+	Reason       string    `firestore:"reason"         json:"reason"`
+	Since        time.Time `firestore:"since"          json:"since"`
+	ExpectedBack time.Time `firestore:"expected_back"  json:"expected_back,omitempty"`
+	StaffID      string    `firestore:"staff_id"       json:"staff_id"`
+}
+
+// InMaintenanceDuring reports whether maintenance keeps the computer out of
+// service at any time in [start, end).
+func (c *Computer) InMaintenanceDuring(start, end time.Time) bool {
+	m := c.Maintenance
+	return m != nil && end.After(m.Since) && (m.ExpectedBack.IsZero() || start.Before(m.ExpectedBack))
+}
+
+// DisplacementReport lists what happened to the future bookings of a
+// computer taken out of service: moved to an equivalent PC, or flagged for
+// staff when none was free.
+type DisplacementReport struct {
+	Computer  *Computer  `json:"computer"`
+	Relocated []*Booking `json:"relocated"`
+	Flagged   []*Booking `json:"flagged"`
+}
+
+// ComputerSpecs is the hardware players choose a PC by.
+type ComputerSpecs struct {
+	GPU         string   `firestore:"gpu"          json:"gpu"` // e.g. "RTX 4070"
+	GPUKey      string   `firestore:"gpu_key"      json:"-"`   // GPU normalised for filtering
+	CPU         string   `firestore:"cpu"          json:"cpu"`
+	RAMGB       int      `firestore:"ram_gb"       json:"ram_gb"`
+	MonitorHz   int      `firestore:"monitor_hz"   json:"monitor_hz"`
+	Peripherals []string `firestore:"peripherals"  json:"peripherals"`
+}
+
+// HardwareKey normalises a hardware model name so "RTX 4070", "rtx-4070"
+// and "rtx4070" match.
+func HardwareKey(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
 }
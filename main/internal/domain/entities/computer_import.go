@@ -0,0 +1,45 @@
+package entities
+
+import "errors"
+
+// MaxImportRows bounds one import so it commits in a single transaction.
+const MaxImportRows = 200
+
+var (
+	ErrDuplicatePCNumber = errors.New("pc number is already used in this club")
+	ErrInvalidPCNumber   = errors.New("pc number must be positive")
+	ErrMissingSpecs      = errors.New("gpu, cpu, ram_gb and monitor_hz are required")
+	ErrImportInvalid     = errors.New("import has invalid rows; nothing was created")
+	ErrImportTooLarge    = errors.New("too many computers in one import")
+)
+
+// ImportRow is one computer read from an import body. Row is its 1-based
+// position, not counting a CSV header; Errors holds problems found while
+// decoding it.
+type ImportRow struct {
+	Row      int
+	Computer Computer
+	Errors   []string
+}
+
+// ImportRowResult is the validation outcome of one row.
+type ImportRowResult struct {
+	Row      int      `json:"row"`
+	PCNumber int      `json:"pc_number"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// ImportReport describes a computer import. Computers is set only when the
+// import was written, which happens for all rows or none.
+type ImportReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Total     int               `json:"total"`
+	Valid     int               `json:"valid"`
+	Rows      []ImportRowResult `json:"rows"`
+	Computers []*Computer       `json:"computers,omitempty"`
+}
+
+// OK reports whether every row passed validation.
+func (r *ImportReport) OK() bool {
+	return r.Valid == r.Total
+}
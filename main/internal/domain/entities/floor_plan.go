@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Seat statuses on a seat map.
+const (
+	SeatFree        = "free"
+	SeatBooked      = "booked"
+	SeatMaintenance = "maintenance"
+	SeatUnavailable = "unavailable"
+	SeatOffline     = "offline"
+)
+
+var (
+	ErrFloorPlanNotFound = errors.New("club has no floor plan")
+	ErrInvalidFloorPlan  = errors.New("invalid floor plan")
+)
+
+// FloorPlan is the layout of a club's rooms. Positions are in grid cells
+// from the top-left corner of a room; fractional values place items between
+// cells for clubs that draw free-form plans.
+type FloorPlan struct {
+	ClubID    string    `firestore:"club_id"     json:"club_id"`
+	Rooms     []Room    `firestore:"rooms"       json:"rooms"`
+	UpdatedAt time.Time `firestore:"updated_at"  json:"updated_at"`
+}
+
+// Room is one hall of a club, Width by Height cells.
+type Room struct {
+	ID        string     `firestore:"id"         json:"id"`
+	Name      string     `firestore:"name"       json:"name"`
+	Width     float64    `firestore:"width"      json:"width"`
+	Height    float64    `firestore:"height"     json:"height"`
+	Walls     []Wall     `firestore:"walls"      json:"walls"`
+	Entrances []Position `firestore:"entrances"  json:"entrances"`
+	Zones     []Zone     `firestore:"zones"      json:"zones"`
+	Seats     []Seat     `firestore:"seats"      json:"seats"`
+}
+
+// Position is a point in a room.
+type Position struct {
+	X float64 `firestore:"x"  json:"x"`
+	Y float64 `firestore:"y"  json:"y"`
+}
+
+// Wall is a straight wall segment.
+type Wall struct {
+	From Position `firestore:"from"  json:"from"`
+	To   Position `firestore:"to"    json:"to"`
+}
+
+// Zone is a labelled rectangle, e.g. the VIP corner.
+type Zone struct {
+	Label  string   `firestore:"label"   json:"label"`
+	At     Position `firestore:"at"      json:"at"`
+	Width  float64  `firestore:"width"   json:"width"`
+	Height float64  `firestore:"height"  json:"height"`
+}
+
+// Seat places a computer in a room. Rotation is in degrees clockwise.
+type Seat struct {
+	ComputerID string   `firestore:"computer_id"  json:"computer_id"`
+	At         Position `firestore:"at"           json:"at"`
+	Rotation   float64  `firestore:"rotation"     json:"rotation"`
+}
+
+// Validate checks room sizes and that every seat, zone, wall and entrance
+// lies inside its room and each computer is placed at most once.
+func (p *FloorPlan) Validate() error {
+	rooms := make(map[string]bool, len(p.Rooms))
+	placed := make(map[string]bool)
+	for _, r := range p.Rooms {
+		if r.ID == "" || rooms[r.ID] {
+			return fmt.Errorf("%w: room IDs must be set and unique", ErrInvalidFloorPlan)
+		}
+		rooms[r.ID] = true
+		if r.Width <= 0 || r.Height <= 0 {
+			return fmt.Errorf("%w: room %s has no size", ErrInvalidFloorPlan, r.ID)
+		}
+		for _, w := range r.Walls {
+			if !r.contains(w.From) || !r.contains(w.To) {
+				return fmt.Errorf("%w: wall outside room %s", ErrInvalidFloorPlan, r.ID)
+			}
+		}
+		for _, e := range r.Entrances {
+			if !r.contains(e) {
+				return fmt.Errorf("%w: entrance outside room %s", ErrInvalidFloorPlan, r.ID)
+			}
+		}
+		for _, z := range r.Zones {
+			end := Position{X: z.At.X + z.Width, Y: z.At.Y + z.Height}
+			if z.Width <= 0 || z.Height <= 0 || !r.contains(z.At) || !r.contains(end) {
+				return fmt.Errorf("%w: zone %q outside room %s", ErrInvalidFloorPlan, z.Label, r.ID)
+			}
+		}
+		for _, s := range r.Seats {
+			if s.ComputerID == "" || placed[s.ComputerID] {
+				return fmt.Errorf("%w: computer %q placed more than once", ErrInvalidFloorPlan, s.ComputerID)
+			}
+			placed[s.ComputerID] = true
+			if !r.contains(s.At) {
+				return fmt.Errorf("%w: seat of computer %s outside room %s", ErrInvalidFloorPlan, s.ComputerID, r.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// ComputerIDs returns the IDs of every placed computer.
+func (p *FloorPlan) ComputerIDs() []string {
+	var ids []string
+	for _, r := range p.Rooms {
+		for _, s := range r.Seats {
+			ids = append(ids, s.ComputerID)
+		}
+	}
+	return ids
+}
+
+func (r Room) contains(p Position) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X <= r.Width && p.Y <= r.Height
+}
+
+// SeatMap is a floor plan with the status of every seat over an interval,
+// for rendering a live seat picker.
+type SeatMap struct {
+	ClubID   string        `json:"club_id"`
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Timezone string        `json:"timezone"`
+	Rooms    []SeatMapRoom `json:"rooms"`
+}
+
+// SeatMapRoom is a room whose seats carry their computer and status.
+type SeatMapRoom struct {
+	Room
+	Seats []MapSeat `json:"seats"`
+}
+
+// MapSeat is a seat on a seat map. Status is one of the Seat constants.
+type MapSeat struct {
+	Seat
+	PCNumber int           `json:"pc_number"`
+	Category string        `json:"category"`
+	Specs    ComputerSpecs `json:"specs"`
+	Status   string        `json:"status"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvoiceNotFound  = errors.New("receipt not found")
+	ErrInvoiceForbidden = errors.New("receipt belongs to another customer or club")
+)
+
+// Invoice is the receipt issued for a paid booking.
+type Invoice struct {
+	ID               string       `firestore:"id"                 json:"id"`
+	Number           string       `firestore:"number"             json:"number"`
+	BookingID        string       `firestore:"booking_id"         json:"booking_id"`
+	UserID           string       `firestore:"user_id"            json:"user_id"`
+	ClubID           string       `firestore:"club_id"            json:"club_id"`
+	ClubName         string       `firestore:"club_name"          json:"club_name"`
+	Seller           LegalDetails `firestore:"seller"             json:"seller"`
+	LineItems        []LineItem   `firestore:"line_items"         json:"line_items"`
+	Subtotal         float64      `firestore:"subtotal"           json:"subtotal"`
+	Discount         float64      `firestore:"discount"           json:"discount"`
+	TaxRate          float64      `firestore:"tax_rate"           json:"tax_rate"`
+	TaxInclusive     bool         `firestore:"tax_inclusive"      json:"tax_inclusive"`
+	TaxID            string       `firestore:"tax_id"             json:"tax_id,omitempty"`
+	TaxAmount        float64      `firestore:"tax_amount"         json:"tax_amount"`
+	Total            float64      `firestore:"total"              json:"total"`
+	Currency         string       `firestore:"currency"           json:"currency"`
+	PaymentMethod    string       `firestore:"payment_method"     json:"payment_method"`
+	PaymentReference string       `firestore:"payment_reference"  json:"payment_reference"`
+	IssuedAt         time.Time    `firestore:"issued_at"          json:"issued_at"`
+	Timezone         string       `firestore:"timezone"           json:"timezone"`
+}
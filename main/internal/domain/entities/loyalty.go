@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// Loyalty transaction types.
+const (
+	PointsEarn     = "earn"
+	PointsBurn     = "burn"
+	PointsRestore  = "restore"
+	PointsClawback = "clawback"
+	PointsExpire   = "expire"
+)
+
+var (
+	ErrInsufficientPoints = errors.New("not enough loyalty points")
+	ErrPointsNotAccepted  = errors.New("points cannot be redeemed at this club")
+)
+
+// LoyaltyConfig holds a club's earn and burn rates.
+type LoyaltyConfig struct {
+	ClubID            string  `firestore:"club_id"              json:"club_id"`
+	Enabled           bool    `firestore:"enabled"              json:"enabled"`
+	EarnPointsPerHour float64 `firestore:"earn_points_per_hour" json:"earn_points_per_hour"`
+	PointValue        float64 `firestore:"point_value"          json:"point_value"`      // currency units one point is worth when burned
+	MaxBurnPercent    float64 `firestore:"max_burn_percent"     json:"max_burn_percent"` // share of the subtotal payable with points; 0 means 100
+	ExpiryDays        int     `firestore:"expiry_days"          json:"expiry_days"`      // 0 means points never expire
+}
+
+// LoyaltyAccount is a user's current points balance at one club; points
+// earned at a club can only be spent there.
+type LoyaltyAccount struct {
+	UserID    string    `firestore:"user_id"     json:"user_id"`
+	ClubID    string    `firestore:"club_id"     json:"club_id"`
+	Balance   int64     `firestore:"balance"     json:"balance"`
+	UpdatedAt time.Time `firestore:"updated_at"  json:"updated_at"`
+}
+
+// LoyaltyTransaction is an append-only points ledger entry. Points is signed.
+// Earn and restore entries double as lots: Remaining tracks how many of
+// their points are still unspent, and burns consume the lots of the club
+// oldest-expiry first.
+type LoyaltyTransaction struct {
+	ID        string    `firestore:"id"          json:"id"`
+	UserID    string    `firestore:"user_id"     json:"user_id"`
+	ClubID    string    `firestore:"club_id"     json:"club_id,omitempty"`
+	Type      string    `firestore:"type"        json:"type"`
+	Points    int64     `firestore:"points"      json:"points"`
+	Remaining int64     `firestore:"remaining"   json:"-"`
+	ExpiresAt time.Time `firestore:"expires_at"  json:"expires_at,omitempty"`
+	BookingID string    `firestore:"booking_id"  json:"booking_id,omitempty"`
+	CreatedAt time.Time `firestore:"created_at"  json:"created_at"`
+}
@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// Membership statuses.
+const (
+	MembershipIncomplete = "incomplete"
+	MembershipActive     = "active"
+	MembershipPastDue    = "past_due"
+	MembershipCancelled  = "cancelled"
+	MembershipExpired    = "expired"
+)
+
+var (
+	ErrMembershipHoursExhausted = errors.New("membership has no included hours left")
+	ErrPlanNotFound             = errors.New("membership plan not found")
+)
+
+// MembershipPlan is a pass a club sells, e.g. "30 hours per month" or
+// "unlimited weekday mornings".
+type MembershipPlan struct {
+	ID            string    `firestore:"id"               json:"id"`
+	ClubID        string    `firestore:"club_id"          json:"club_id"`
+	Name          string    `firestore:"name"             json:"name"`
+	Description   string    `firestore:"description"      json:"description"`
+	Price         float64   `firestore:"price"            json:"price"`
+	Currency      string    `firestore:"currency"         json:"currency"`
+	IntervalCount int       `firestore:"interval_count"   json:"interval_count"`
+	IncludedHours float64   `firestore:"included_hours"   json:"included_hours"` // 0 means unlimited
+	Weekdays      []int     `firestore:"weekdays"         json:"weekdays"`       // time.Weekday values; empty means every day
+	StartHour     int       `firestore:"start_hour"       json:"start_hour"`
+	EndHour       int       `firestore:"end_hour"         json:"end_hour"` // StartHour == EndHour == 0 means all day
+	StripePriceID string    `firestore:"stripe_price_id"  json:"-"`
+	Active        bool      `firestore:"active"           json:"active"`
+	CreatedAt     time.Time `firestore:"created_at"       json:"created_at"`
+}
+
+// Unlimited reports whether the plan has no cap on included hours.
+func (p *MembershipPlan) Unlimited() bool {
+	return p.IncludedHours <= 0
+}
+
+// CoveredHours returns how many hours of [start, end) fall inside the plan's
+// weekday and hour window, evaluated in start's location. Windows whose
+// EndHour is not after StartHour span midnight.
+func (p *MembershipPlan) CoveredHours(start, end time.Time) float64 {
+	if !end.After(start) {
+		return 0
+	}
+	if len(p.Weekdays) == 0 && p.StartHour == 0 && p.EndHour == 0 {
+		return end.Sub(start).Hours()
+	}
+	loc := start.Location()
+	// begin a day early so an overnight window from the previous day counts
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, -1)
+	var total time.Duration
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !p.coversWeekday(day.Weekday()) {
+			continue
+		}
+		ws := day.Add(time.Duration(p.StartHour) * time.Hour)
+		we := day.Add(time.Duration(p.EndHour) * time.Hour)
+		if p.StartHour == 0 && p.EndHour == 0 {
+			we = day.AddDate(0, 0, 1)
+		} else if p.EndHour <= p.StartHour {
+			we = we.AddDate(0, 0, 1)
+		}
+		total += overlap(start, end, ws, we)
+	}
+	return total.Hours()
+}
+
+func (p *MembershipPlan) coversWeekday(d time.Weekday) bool {
+	if len(p.Weekdays) == 0 {
+		return true
+	}
+	for _, w := range p.Weekdays {
+		if time.Weekday(w) == d {
+			return true
+		}
+	}
+	return false
+}
+
+func overlap(aStart, aEnd, bStart, bEnd time.Time) time.Duration {
+	s, e := aStart, aEnd
+	if bStart.After(s) {
+		s = bStart
+	}
+	if bEnd.Before(e) {
+		e = bEnd
+	}
+	if !e.After(s) {
+		return 0
+	}
+	return e.Sub(s)
+}
+
+// Membership is a user's subscription to a MembershipPlan.
+type Membership struct {
+	ID                   string    `firestore:"id"                      json:"id"`
+	UserID               string    `firestore:"user_id"                 json:"user_id"`
+	PlanID               string    `firestore:"plan_id"                 json:"plan_id"`
+	ClubID               string    `firestore:"club_id"                 json:"club_id"`
+	Status               string    `firestore:"status"                  json:"status"`
+	CurrentPeriodStart   time.Time `firestore:"current_period_start"    json:"current_period_start"`
+	CurrentPeriodEnd     time.Time `firestore:"current_period_end"      json:"current_period_end"`
+	HoursUsed            float64   `firestore:"hours_used"              json:"hours_used"`
+	CancelAtPeriodEnd    bool      `firestore:"cancel_at_period_end"    json:"cancel_at_period_end"`
+	StripeCustomerID     string    `firestore:"stripe_customer_id"      json:"-"`
+	StripeSubscriptionID string    `firestore:"stripe_subscription_id"  json:"-"`
+	CreatedAt            time.Time `firestore:"created_at"              json:"created_at"`
+}
+
+// UsableAt reports whether the membership grants access at t.
+func (m *Membership) UsableAt(t time.Time) bool {
+	return m.Status == MembershipActive &&
+		!t.Before(m.CurrentPeriodStart) && t.Before(m.CurrentPeriodEnd)
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrClubClosed   = errors.New("club is closed during the requested interval")
+	ErrInvalidHours = errors.New("invalid opening hours")
+)
+
+// OpeningHours is a club's schedule. Times are wall-clock "HH:MM" and are
+// evaluated in the location of the time being checked.
+type OpeningHours struct {
+	AlwaysOpen bool        `firestore:"always_open"  json:"always_open"`
+	Weekly     []DayHours  `firestore:"weekly"       json:"weekly"`
+	Exceptions []DateHours `firestore:"exceptions"   json:"exceptions"`
+	Closures   []Closure   `firestore:"closures"     json:"closures"`
+}
+
+// DayHours is one opening span starting on a weekday. A Close at or before
+// Open runs past midnight into the next day; Open equal to Close is 24 hours.
+type DayHours struct {
+	Day   time.Weekday `firestore:"day"    json:"day"`
+	Open  string       `firestore:"open"   json:"open"`
+	Close string       `firestore:"close"  json:"close"`
+}
+
+// DateHours replaces the weekly hours on one date, e.g. a holiday.
+type DateHours struct {
+	Date   string `firestore:"date"    json:"date"` // YYYY-MM-DD
+	Closed bool   `firestore:"closed"  json:"closed"`
+	Open   string `firestore:"open"    json:"open,omitempty"`
+	Close  string `firestore:"close"   json:"close,omitempty"`
+	Reason string `firestore:"reason"  json:"reason,omitempty"`
+}
+
+// Closure shuts the club over an absolute period regardless of hours.
+type Closure struct {
+	From   time.Time `firestore:"from"    json:"from"`
+	To     time.Time `firestore:"to"      json:"to"`
+	Reason string    `firestore:"reason"  json:"reason"`
+}
+
+// Validate checks the wall-clock values and periods of h.
+func (h *OpeningHours) Validate() error {
+	for _, d := range h.Weekly {
+		if d.Day < time.Sunday || d.Day > time.Saturday {
+			return ErrInvalidHours
+		}
+		if _, err := parseClock(d.Open); err != nil {
+			return ErrInvalidHours
+		}
+		if _, err := parseClock(d.Close); err != nil {
+			return ErrInvalidHours
+		}
+	}
+	for _, e := range h.Exceptions {
+		if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+			return ErrInvalidHours
+		}
+		if e.Closed {
+			continue
+		}
+		if _, err := parseClock(e.Open); err != nil {
+			return ErrInvalidHours
+		}
+		if _, err := parseClock(e.Close); err != nil {
+			return ErrInvalidHours
+		}
+	}
+	for _, c := range h.Closures {
+		if !c.To.After(c.From) {
+			return ErrInvalidHours
+		}
+	}
+	return nil
+}
+
+// Check returns nil if the club is open for the whole of [start, end) and
+// otherwise an error wrapping ErrClubClosed that carries the reason if known.
+// A nil schedule places no restriction.
+func (h *OpeningHours) Check(start, end time.Time) error {
+	if h == nil {
+		return nil
+	}
+	for _, c := range h.Closures {
+		if start.Before(c.To) && c.From.Before(end) {
+			return closedErr(c.Reason)
+		}
+	}
+	if h.AlwaysOpen {
+		return nil
+	}
+	// spans can start the day before and run overnight into start's day
+	var spans []span
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()).AddDate(0, 0, -1)
+	for !day.After(end) {
+		spans = append(spans, h.spansOn(day)...)
+		day = day.AddDate(0, 0, 1)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].from.Before(spans[j].from) })
+	cursor := start
+	for _, s := range spans {
+		if !cursor.Before(end) {
+			break
+		}
+		if s.from.After(cursor) {
+			break
+		}
+		if s.to.After(cursor) {
+			cursor = s.to
+		}
+	}
+	if cursor.Before(end) {
+		return closedErr(h.reasonOn(cursor))
+	}
+	return nil
+}
+
+// IsOpenAt reports whether the club is open at t.
+func (h *OpeningHours) IsOpenAt(t time.Time) bool {
+	return h.Check(t, t.Add(time.Minute)) == nil
+}
+
+type span struct{ from, to time.Time }
+
+// spansOn returns the opening spans starting on day (midnight).
+func (h *OpeningHours) spansOn(day time.Time) []span {
+	date := day.Format("2006-01-02")
+	for _, e := range h.Exceptions {
+		if e.Date != date {
+			continue
+		}
+		if e.Closed {
+			return nil
+		}
+		return []span{clockSpan(day, e.Open, e.Close)}
+	}
+	var out []span
+	for _, d := range h.Weekly {
+		if d.Day == day.Weekday() {
+			out = append(out, clockSpan(day, d.Open, d.Close))
+		}
+	}
+	return out
+}
+
+// reasonOn returns the reason of a date exception covering t, if any.
+func (h *OpeningHours) reasonOn(t time.Time) string {
+	date := t.Format("2006-01-02")
+	for _, e := range h.Exceptions {
+		if e.Date == date {
+			return e.Reason
+		}
+	}
+	return ""
+}
+
+func clockSpan(day time.Time, open, close string) span {
+	o, _ := parseClock(open)
+	c, _ := parseClock(close)
+	from := day.Add(o)
+	to := day.Add(c)
+	if c <= o {
+		to = to.AddDate(0, 0, 1)
+	}
+	return span{from: from, to: to}
+}
+
+// parseClock parses "HH:MM" into an offset from midnight; "24:00" is allowed.
+func parseClock(s string) (time.Duration, error) {
+	var hh, mm int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hh, &mm); err != nil {
+		return 0, err
+	}
+	if hh < 0 || mm < 0 || mm > 59 || hh > 24 || (hh == 24 && mm != 0) {
+		return 0, ErrInvalidHours
+	}
+	return time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute, nil
+}
+
+func closedErr(reason string) error {
+	if reason == "" {
+		return ErrClubClosed
+	}
+	return fmt.Errorf("%w: %s", ErrClubClosed, reason)
+}
@@ -0,0 +1,74 @@
+package entities
+
+import "time"
+
+// Payment ledger entry types.
+const (
+	LedgerIntent = "intent"
+	LedgerCharge = "charge"
+	LedgerRefund = "refund"
+	LedgerFee    = "fee"
+)
+
+// PaymentLedgerEntry records one money movement at the payment provider or,
+// for offline payments, at a club's front desk; Provider is then the payment
+// method and StaffID the staff member who took the money.
+// Amount is in minor units and signed from the platform's point of view:
+// charges are positive, refunds and fees negative. Intents carry the
+// requested amount but move no money.
+type PaymentLedgerEntry struct {
+	ID          string    `firestore:"id"            json:"id"`
+	Type        string    `firestore:"type"          json:"type"`
+	Provider    string    `firestore:"provider"      json:"provider"`
+	ProviderRef string    `firestore:"provider_ref"  json:"provider_ref"`
+	IntentRef   string    `firestore:"intent_ref"    json:"intent_ref,omitempty"`
+	Purpose     string    `firestore:"purpose"       json:"purpose,omitempty"`
+	BookingID   string    `firestore:"booking_id"    json:"booking_id,omitempty"`
+	ClubID      string    `firestore:"club_id"       json:"club_id,omitempty"`
+	StaffID     string    `firestore:"staff_id"      json:"staff_id,omitempty"`
+	UserID      string    `firestore:"user_id"       json:"user_id,omitempty"`
+	Amount      int64     `firestore:"amount"        json:"amount"`
+	Currency    string    `firestore:"currency"      json:"currency"`
+	CreatedAt   time.Time `firestore:"created_at"    json:"created_at"`
+}
+
+// ProviderTransaction is a balance transaction as reported by the payment
+// provider, e.g. a Stripe balance transaction.
+type ProviderTransaction struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	SourceID string    `json:"source_id"`
+	Amount   int64     `json:"amount"`
+	Fee      int64     `json:"fee"`
+	Currency string    `json:"currency"`
+	Created  time.Time `json:"created"`
+}
+
+// Reconciliation mismatch kinds.
+const (
+	MismatchMissingInLedger   = "missing_in_ledger"
+	MismatchMissingAtProvider = "missing_at_provider"
+	MismatchAmount            = "amount_mismatch"
+	MismatchFee               = "fee_mismatch"
+)
+
+// ReconciliationMismatch is a single discrepancy between ledger and provider.
+type ReconciliationMismatch struct {
+	Kind           string `firestore:"kind"             json:"kind"`
+	ProviderRef    string `firestore:"provider_ref"     json:"provider_ref"`
+	LedgerAmount   int64  `firestore:"ledger_amount"    json:"ledger_amount"`
+	ProviderAmount int64  `firestore:"provider_amount"  json:"provider_amount"`
+}
+
+// ReconciliationReport is the outcome of comparing one day of the ledger
+// with the provider. ID is the day in YYYY-MM-DD form.
+type ReconciliationReport struct {
+	ID            string                   `firestore:"id"              json:"id"`
+	From          time.Time                `firestore:"from"            json:"from"`
+	To            time.Time                `firestore:"to"              json:"to"`
+	ProviderCount int                      `firestore:"provider_count"  json:"provider_count"`
+	LedgerCount   int                      `firestore:"ledger_count"    json:"ledger_count"`
+	Matched       int                      `firestore:"matched"         json:"matched"`
+	Mismatches    []ReconciliationMismatch `firestore:"mismatches"      json:"mismatches"`
+	CreatedAt     time.Time                `firestore:"created_at"      json:"created_at"`
+}
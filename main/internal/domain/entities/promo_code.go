@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// Discount types supported by PromoCode.
+const (
+	DiscountPercent   = "percent"
+	DiscountFixed     = "fixed"
+	DiscountFreeHours = "free_hours"
+)
+
+var (
+	ErrPromoCodeNotFound     = errors.New("promo code not found")
+	ErrPromoCodeInactive     = errors.New("promo code is not active")
+	ErrPromoCodeExpired      = errors.New("promo code is outside its validity window")
+	ErrPromoCodeWrongClub    = errors.New("promo code is not valid for this club")
+	ErrPromoCodeMinSpend     = errors.New("order total is below promo code minimum spend")
+	ErrPromoCodeFirstBooking = errors.New("promo code is only valid for the first booking")
+	ErrPromoCodeExhausted    = errors.New("promo code usage limit reached")
+	ErrPromoCodeUserLimit    = errors.New("promo code already used the maximum number of times")
+)
+
+// PromoCode is the domain entity representing a discount campaign code.
+type PromoCode struct {
+	ID               string    `firestore:"id"                  json:"id"`
+	Code             string    `firestore:"code"                json:"code"`
+	Description      string    `firestore:"description"         json:"description"`
+	DiscountType     string    `firestore:"discount_type"       json:"discount_type"`
+	DiscountValue    float64   `firestore:"discount_value"      json:"discount_value"`
+	ValidFrom        time.Time `firestore:"valid_from"          json:"valid_from"`
+	ValidUntil       time.Time `firestore:"valid_until"         json:"valid_until"`
+	MaxUses          int       `firestore:"max_uses"            json:"max_uses"`
+	MaxUsesPerUser   int       `firestore:"max_uses_per_user"   json:"max_uses_per_user"`
+	UsedCount        int       `firestore:"used_count"          json:"used_count"`
+	ClubIDs          []string  `firestore:"club_ids"            json:"club_ids"`
+	MinSpend         float64   `firestore:"min_spend"           json:"min_spend"`
+	FirstBookingOnly bool      `firestore:"first_booking_only"  json:"first_booking_only"`
+	Active           bool      `firestore:"active"              json:"active"`
+	CreatedAt        time.Time `firestore:"created_at"          json:"created_at"`
+}
+
+// AppliesToClub reports whether the code may be used at the given club.
+// An empty ClubIDs list means the code is valid platform-wide.
+func (p *PromoCode) AppliesToClub(clubID string) bool {
+	if len(p.ClubIDs) == 0 {
+		return true
+	}
+	for _, id := range p.ClubIDs {
+		if id == clubID {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidAt reports whether t falls inside the validity window.
+// Zero bounds are treated as open.
+func (p *PromoCode) ValidAt(t time.Time) bool {
+	if !p.ValidFrom.IsZero() && t.Before(p.ValidFrom) {
+		return false
+	}
+	if !p.ValidUntil.IsZero() && t.After(p.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// DiscountFor returns the discount the code grants on the given subtotal,
+// never exceeding the subtotal itself.
+func (p *PromoCode) DiscountFor(subtotal, pricePerHour float64) float64 {
+	var d float64
+	switch p.DiscountType {
+	case DiscountPercent:
+		d = subtotal * p.DiscountValue / 100
+	case DiscountFixed:
+		d = p.DiscountValue
+	case DiscountFreeHours:
+		d = p.DiscountValue * pricePerHour
+	}
+	if d > subtotal {
+		d = subtotal
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
+
+// PromoRedemption records a single use of a promo code by a user.
+// FirstBooking marks uses of first-booking codes.
+type PromoRedemption struct {
+	ID           string    `firestore:"id"             json:"id"`
+	PromoCodeID  string    `firestore:"promo_code_id"  json:"promo_code_id"`
+	UserID       string    `firestore:"user_id"        json:"user_id"`
+	FirstBooking bool      `firestore:"first_booking"  json:"first_booking,omitempty"`
+	CreatedAt    time.Time `firestore:"created_at"     json:"created_at"`
+}
@@ -0,0 +1,56 @@
+package entities
+
+import "time"
+
+// Line item kinds used in Quote.
+const (
+	LineItemTime       = "time"
+	LineItemMembership = "membership"
+	LineItemDiscount   = "discount"
+	LineItemPoints     = "points"
+	LineItemTax        = "tax"
+)
+
+// QuoteRequest describes the booking a user wants priced.
+type QuoteRequest struct {
+	UserID       string    `json:"-"`
+	ClubID       string    `json:"club_id"`
+	PCNumber     int       `json:"pc_number"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	PromoCode    string    `json:"promo_code,omitempty"`
+	RedeemPoints int64     `json:"redeem_points,omitempty"`
+}
+
+// LineItem is a single priced row of a Quote.
+type LineItem struct {
+	Kind        string  `firestore:"kind"        json:"kind"`
+	Description string  `firestore:"description" json:"description"`
+	Quantity    float64 `firestore:"quantity"    json:"quantity"`
+	UnitPrice   float64 `firestore:"unit_price"  json:"unit_price"`
+	Amount      float64 `firestore:"amount"      json:"amount"`
+}
+
+// Quote is the priced breakdown of a prospective booking.
+type Quote struct {
+	ClubID       string     `json:"club_id"`
+	PCNumber     int        `json:"pc_number"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      time.Time  `json:"end_time"`
+	Timezone     string     `json:"timezone"`
+	StartLocal   string     `json:"start_time_local"`
+	EndLocal     string     `json:"end_time_local"`
+	Hours        float64    `json:"hours"`
+	LineItems    []LineItem `json:"line_items"`
+	Subtotal     float64    `json:"subtotal"`
+	Discount     float64    `json:"discount"`
+	TaxRate      float64    `json:"tax_rate"`
+	TaxInclusive bool       `json:"tax_inclusive"`
+	TaxAmount    float64    `json:"tax_amount"`
+	Total        float64    `json:"total"`
+	PromoCodeID  string     `json:"promo_code_id,omitempty"`
+	PromoCode    string     `json:"promo_code,omitempty"`
+	MembershipID string     `json:"membership_id,omitempty"`
+	CoveredHours float64    `json:"covered_hours,omitempty"`
+	PointsUsed   int64      `json:"points_used,omitempty"`
+}
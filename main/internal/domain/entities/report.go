@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+// RevenueReport summarises paid bookings of a club over a period.
+type RevenueReport struct {
+	ClubID    string         `json:"club_id"`
+	Timezone  string         `json:"timezone"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Bookings  int            `json:"bookings"`
+	Discounts float64        `json:"discounts"`
+	Net       float64        `json:"net"`
+	Tax       float64        `json:"tax"`
+	Gross     float64        `json:"gross"`
+	ByTaxRate []TaxBreakdown `json:"by_tax_rate"`
+}
+
+// CashReport sums offline payments taken at a club's front desk over a shift.
+type CashReport struct {
+	ClubID   string                `json:"club_id"`
+	Timezone string                `json:"timezone"`
+	StaffID  string                `json:"staff_id,omitempty"`
+	From     time.Time             `json:"from"`
+	To       time.Time             `json:"to"`
+	Payments int                   `json:"payments"`
+	Refunds  int                   `json:"refunds"`
+	Total    float64               `json:"total"`
+	ByMethod []CashMethodBreakdown `json:"by_method"`
+	Entries  []*PaymentLedgerEntry `json:"entries"`
+	Names    map[string]string     `json:"names"` // staff ID to name
+}
+
+// CashMethodBreakdown is the part of a CashReport taken with one method.
+// Cash is what should be in the till at the end of the shift.
+type CashMethodBreakdown struct {
+	Method   string  `json:"method"`
+	Payments int     `json:"payments"`
+	Refunds  int     `json:"refunds"`
+	Amount   float64 `json:"amount"`
+}
+
+// TaxBreakdown is the part of a RevenueReport booked at one tax rate.
+type TaxBreakdown struct {
+	Rate     float64 `json:"rate"`
+	Bookings int     `json:"bookings"`
+	Net      float64 `json:"net"`
+	Tax      float64 `json:"tax"`
+	Gross    float64 `json:"gross"`
+}
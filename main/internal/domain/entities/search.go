@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"errors"
+	"main/internal/domain/geo"
+)
+
+// ErrInvalidCursor is returned for a pagination cursor that does not come
+// from a previous page.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Club sort orders.
+const (
+	ClubSortName     = "name"
+	ClubSortPrice    = "price"
+	ClubSortRating   = "rating"
+	ClubSortDistance = "distance"
+)
+
+// ClubFilter narrows a club listing; zero values mean no constraint.
+// Near and Radius switch to a search around a point.
+type ClubFilter struct {
+	City        string
+	MinPrice    float64
+	MaxPrice    float64
+	HasFreePCs  bool
+	OpenNow     bool
+	Amenities   []string
+	MinGPUClass int
+	Near        *geo.Point
+	Radius      float64 // meters
+	SortBy      string
+	Desc        bool
+	Cursor      string
+	Limit       int
+}
+
+// ClubPage is one page of club search results. NextCursor is empty on the
+// last page.
+type ClubPage struct {
+	Items      []*ClubDistance `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// ComputerFilter narrows a computer listing; zero values mean no constraint.
+type ComputerFilter struct {
+	ClubID      string
+	Category    string
+	GPU         string // matched against ComputerSpecs.GPUKey
+	MinHz       int
+	MinRAMGB    int
+	Available   *bool
+	MinGPUClass int
+	Cursor      string
+	Limit       int
+}
+
+// ComputerPage is one page of computer search results.
+type ComputerPage struct {
+	Items      []*Computer `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Languages the apps are translated into; DefaultLanguage applies otherwise.
+const DefaultLanguage = "ru"
+
+var supportedLanguages = map[string]bool{"ru": true, "kk": true, "en": true}
+
+// MaxNicknameLength bounds a nickname in characters.
+const MaxNicknameLength = 32
+
+// Roles a user can sign in with, carried in the "role" custom claim. Users
+// without one are customers. Staff also carry the "club_id" claim of the
+// club they work at.
+const (
+	RoleAdmin = "admin"
+	RoleOwner = "owner"
+	RoleStaff = "staff"
+)
+
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrInvalidProfile = errors.New("invalid profile")
+	ErrInvalidRole    = errors.New("role must be admin, owner, staff or empty")
+	ErrStaffClub      = errors.New("staff must be assigned a club")
+)
+
+// IsValidRole reports whether role can be assigned; empty removes a role.
+func IsValidRole(role string) bool {
+	switch role {
+	case "", RoleAdmin, RoleOwner, RoleStaff:
+		return true
+	}
+	return false
+}
+
+// User is the profile the backend keeps next to a Firebase account; ID is
+// the Firebase UID. BirthDate is YYYY-MM-DD as declared by the user; only
+// the date in AgeVerification, set by staff, gates age-restricted hours.
+type User struct {
+	ID              string          `firestore:"id"                json:"id"`
+	DisplayName     string          `firestore:"display_name"      json:"display_name"`
+	Email           string          `firestore:"email"             json:"email,omitempty"`
+	Phone           string          `firestore:"phone"             json:"phone,omitempty"`
+	Nickname        string          `firestore:"nickname"          json:"nickname,omitempty"`
+	BirthDate       string          `firestore:"birth_date"        json:"birth_date,omitempty"`
+	AgeVerification AgeVerification `firestore:"age_verification"  json:"age_verification"`
+	PreferredClubs  []string        `firestore:"preferred_clubs"   json:"preferred_clubs"`
+	Language        string          `firestore:"language"          json:"language"`
+	CreatedAt       time.Time       `firestore:"created_at"        json:"created_at"`
+	UpdatedAt       time.Time       `firestore:"updated_at"        json:"updated_at"`
+}
+
+// Name returns what staff and other players see: the nickname, else the
+// display name, else the UID.
+func (u *User) Name() string {
+	switch {
+	case u.Nickname != "":
+		return u.Nickname
+	case u.DisplayName != "":
+		return u.DisplayName
+	}
+	return u.ID
+}
+
+// UserPatch is a partial profile update; nil fields are left unchanged.
+type UserPatch struct {
+	DisplayName    *string   `json:"display_name"`
+	Phone          *string   `json:"phone"`
+	Nickname       *string   `json:"nickname"`
+	BirthDate      *string   `json:"birth_date"`
+	PreferredClubs *[]string `json:"preferred_clubs"`
+	Language       *string   `json:"language"`
+}
+
+// Apply validates p and copies its fields onto u.
+func (p *UserPatch) Apply(u *User, now time.Time) error {
+	if p.DisplayName != nil {
+		u.DisplayName = strings.TrimSpace(*p.DisplayName)
+	}
+	if p.Phone != nil {
+		phone := strings.TrimSpace(*p.Phone)
+		if phone != "" && !validPhone(phone) {
+			return fmt.Errorf("%w: phone must be + and 10 to 15 digits", ErrInvalidProfile)
+		}
+		u.Phone = phone
+	}
+	if p.Nickname != nil {
+		nick := strings.TrimSpace(*p.Nickname)
+		if utf8.RuneCountInString(nick) > MaxNicknameLength {
+			return fmt.Errorf("%w: nickname longer than %d characters", ErrInvalidProfile, MaxNicknameLength)
+		}
+		u.Nickname = nick
+	}
+	if p.BirthDate != nil {
+		if *p.BirthDate != "" {
+			d, err := time.Parse("2006-01-02", *p.BirthDate)
+			if err != nil || d.After(now) {
+				return fmt.Errorf("%w: birth_date must be a past YYYY-MM-DD date", ErrInvalidProfile)
+			}
+		}
+		u.BirthDate = *p.BirthDate
+	}
+	if p.PreferredClubs != nil {
+		u.PreferredClubs = *p.PreferredClubs
+	}
+	if p.Language != nil {
+		if !supportedLanguages[*p.Language] {
+			return fmt.Errorf("%w: unsupported language %q", ErrInvalidProfile, *p.Language)
+		}
+		u.Language = *p.Language
+	}
+	return nil
+}
+
+// Fields names the stored fields p changes.
+func (p *UserPatch) Fields() []string {
+	var fields []string
+	if p.DisplayName != nil {
+		fields = append(fields, "display_name")
+	}
+	if p.Phone != nil {
+		fields = append(fields, "phone")
+	}
+	if p.Nickname != nil {
+		fields = append(fields, "nickname")
+	}
+	if p.BirthDate != nil {
+		fields = append(fields, "birth_date")
+	}
+	if p.PreferredClubs != nil {
+		fields = append(fields, "preferred_clubs")
+	}
+	if p.Language != nil {
+		fields = append(fields, "language")
+	}
+	return fields
+}
+
+// validPhone accepts international numbers like +77011234567.
+func validPhone(s string) bool {
+	if !strings.HasPrefix(s, "+") || len(s) < 11 || len(s) > 16 {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
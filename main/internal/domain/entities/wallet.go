@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// Wallet transaction types.
+const (
+	WalletTopUp      = "topup"
+	WalletCharge     = "booking_charge"
+	WalletRefund     = "refund"
+	WalletAdjustment = "adjustment"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient wallet balance")
+
+// Wallet is the prepaid balance of a single user. It is derived from the
+// ledger of WalletTransactions and is only ever changed together with one.
+type Wallet struct {
+	UserID    string    `firestore:"user_id"     json:"user_id"`
+	Balance   float64   `firestore:"balance"     json:"balance"`
+	UpdatedAt time.Time `firestore:"updated_at"  json:"updated_at"`
+}
+
+// WalletTransaction is an append-only ledger entry. Amount is signed:
+// credits are positive, debits negative.
+type WalletTransaction struct {
+	ID              string    `firestore:"id"                 json:"id"`
+	UserID          string    `firestore:"user_id"            json:"user_id"`
+	Type            string    `firestore:"type"               json:"type"`
+	Amount          float64   `firestore:"amount"             json:"amount"`
+	BalanceAfter    float64   `firestore:"balance_after"      json:"balance_after"`
+	BookingID       string    `firestore:"booking_id"         json:"booking_id,omitempty"`
+	PaymentIntentID string    `firestore:"payment_intent_id"  json:"payment_intent_id,omitempty"`
+	ActorUID        string    `firestore:"actor_uid"          json:"actor_uid,omitempty"`
+	Note            string    `firestore:"note"               json:"note,omitempty"`
+	CreatedAt       time.Time `firestore:"created_at"         json:"created_at"`
+}
@@ -0,0 +1,131 @@
+// Package geo provides the geohash and distance helpers used for nearby
+// search. Storage backends only need to support range scans over a geohash
+// string to serve CoveringRanges.
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	earthRadius     = 6371000.0 // meters
+	metersPerDegree = 111320.0
+	base32          = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+	// MaxPrecision is the geohash length stored on entities (~1 m cells).
+	MaxPrecision = 10
+)
+
+// Point is a WGS 84 coordinate.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Valid reports whether p lies within coordinate bounds.
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// Range is an inclusive range of geohash strings.
+type Range struct {
+	Start string
+	End   string
+}
+
+// Encode returns the geohash of p with the given number of characters.
+func Encode(p Point, precision int) string {
+	latMin, latMax := -90.0, 90.0
+	lngMin, lngMax := -180.0, 180.0
+	var sb strings.Builder
+	bit, ch, even := 0, 0, true
+	for sb.Len() < precision {
+		if even {
+			mid := (lngMin + lngMax) / 2
+			if p.Lng >= mid {
+				ch = ch<<1 | 1
+				lngMin = mid
+			} else {
+				ch <<= 1
+				lngMax = mid
+			}
+		} else {
+			mid := (latMin + latMax) / 2
+			if p.Lat >= mid {
+				ch = ch<<1 | 1
+				latMin = mid
+			} else {
+				ch <<= 1
+				latMax = mid
+			}
+		}
+		even = !even
+		if bit++; bit == 5 {
+			sb.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return sb.String()
+}
+
+// Distance returns the great-circle distance between a and b in meters.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// cellSize returns the height and width in degrees of a geohash cell.
+func cellSize(precision int) (lat, lng float64) {
+	bits := 5 * precision
+	return 180 / math.Pow(2, float64(bits/2)), 360 / math.Pow(2, float64((bits+1)/2))
+}
+
+// CoveringRanges returns geohash ranges whose union contains every point
+// within radius meters of center. Results are a superset: callers filter
+// candidates by Distance.
+func CoveringRanges(center Point, radius float64) []Range {
+	cos := math.Max(math.Cos(center.Lat*math.Pi/180), 0.01)
+	precision := 1
+	for p := MaxPrecision; p >= 1; p-- {
+		lat, lng := cellSize(p)
+		if lat*metersPerDegree >= radius && lng*metersPerDegree*cos >= radius {
+			precision = p
+			break
+		}
+	}
+	// the center cell and its eight neighbours cover the circle because each
+	// cell is at least radius wide
+	lat, lng := cellSize(precision)
+	seen := map[string]bool{}
+	var out []Range
+	for _, dLat := range []float64{-lat, 0, lat} {
+		for _, dLng := range []float64{-lng, 0, lng} {
+			p := Point{
+				Lat: math.Max(-90, math.Min(90, center.Lat+dLat)),
+				Lng: wrapLng(center.Lng + dLng),
+			}
+			h := Encode(p, precision)
+			if seen[h] {
+				continue
+			}
+			seen[h] = true
+			out = append(out, Range{Start: h, End: h + "~"})
+		}
+	}
+	return out
+}
+
+func wrapLng(lng float64) float64 {
+	for lng < -180 {
+		lng += 360
+	}
+	for lng >= 180 {
+		lng -= 360
+	}
+	return lng
+}
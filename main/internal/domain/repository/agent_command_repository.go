@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"time"
+)
+
+// AgentCommandRepository defines persistence operations for the per-computer
+// command queue.
+type AgentCommandRepository interface {
+	FindByID(ctx context.Context, id string) (*entities.AgentCommand, error)
+	// FindByComputer returns the latest commands of a computer, newest first.
+	FindByComputer(ctx context.Context, computerID string, limit int) ([]*entities.AgentCommand, error)
+	// FindOpenByComputer returns pending and delivered commands of a
+	// computer in NotBefore order.
+	FindOpenByComputer(ctx context.Context, computerID string) ([]*entities.AgentCommand, error)
+	FindOpenByBooking(ctx context.Context, bookingID string) ([]*entities.AgentCommand, error)
+	// FindOpenExpiredBefore returns pending and delivered commands whose
+	// ExpiresAt is before t.
+	FindOpenExpiredBefore(ctx context.Context, t time.Time) ([]*entities.AgentCommand, error)
+	Create(ctx context.Context, cmd *entities.AgentCommand) error
+	Update(ctx context.Context, cmd *entities.AgentCommand) error
+	// Claim saves cmd only if the stored command still has the status and
+	// attempts it was read with, and reports whether it did, so polls,
+	// acks, cancels and expiry racing for a command do not overwrite each
+	// other.
+	Claim(ctx context.Context, cmd *entities.AgentCommand, status string, attempts int) (bool, error)
+}
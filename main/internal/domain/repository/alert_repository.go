@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// AlertRepository defines persistence operations for staff alerts.
+type AlertRepository interface {
+	FindByID(ctx context.Context, id string) (*entities.Alert, error)
+	FindOpenByClub(ctx context.Context, clubID string) ([]*entities.Alert, error)
+	FindOpenByComputer(ctx context.Context, computerID string) ([]*entities.Alert, error)
+	Create(ctx context.Context, a *entities.Alert) error
+	Update(ctx context.Context, a *entities.Alert) error
+}
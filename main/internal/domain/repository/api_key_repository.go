@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"time"
+)
+
+// APIKeyRepository defines persistence operations for club API keys.
+type APIKeyRepository interface {
+	FindByID(ctx context.Context, id string) (*entities.APIKey, error)
+	// FindByPrefix returns the key with the given public prefix, or
+	// ErrAPIKeyNotFound.
+	FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	FindByClub(ctx context.Context, clubID string) ([]*entities.APIKey, error)
+	FindByComputer(ctx context.Context, computerID string) ([]*entities.APIKey, error)
+	Create(ctx context.Context, k *entities.APIKey) error
+	Update(ctx context.Context, k *entities.APIKey) error
+	// Touch records that key id was used at t without rewriting the rest
+	// of the key.
+	Touch(ctx context.Context, id string, t time.Time) error
+}
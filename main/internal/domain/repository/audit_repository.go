@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"time"
+)
+
+// AuditRepository defines persistence operations for the audit log. Entries
+// are never changed once written.
+type AuditRepository interface {
+	Create(ctx context.Context, e *entities.AuditEntry) error
+	// Search returns a page of entries matching f, newest first, and the
+	// cursor of the next page.
+	Search(ctx context.Context, f entities.AuditFilter) ([]*entities.AuditEntry, string, error)
+	// DeleteBefore removes entries older than t and returns how many.
+	DeleteBefore(ctx context.Context, t time.Time) (int, error)
+}
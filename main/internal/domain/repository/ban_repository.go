@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// BanRepository defines persistence operations for user bans.
+type BanRepository interface {
+	FindByID(ctx context.Context, id string) (*entities.Ban, error)
+	// FindByUser returns every ban of the user, lifted and expired ones
+	// included, newest first.
+	FindByUser(ctx context.Context, userID string) ([]*entities.Ban, error)
+	// FindByClub returns the bans issued at clubID, newest first; an empty
+	// clubID returns platform-wide bans.
+	FindByClub(ctx context.Context, clubID string) ([]*entities.Ban, error)
+	Create(ctx context.Context, b *entities.Ban) error
+	Update(ctx context.Context, b *entities.Ban) error
+}
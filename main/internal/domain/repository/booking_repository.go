@@ -3,12 +3,32 @@ package repository
 import (
 	"context"
 	"main/internal/domain/entities"
+	"time"
 )
 
 // BookingRepository defines persistence operations for Booking.
 type BookingRepository interface {
 	FindAllByUser(ctx context.Context, userID string) ([]*entities.Booking, error)
 	FindByID(ctx context.Context, id string) (*entities.Booking, error)
+	// CountByUser counts the user's bookings that are held or paid for.
+	CountByUser(ctx context.Context, userID string) (int, error)
+	// FindByClub returns bookings at clubID starting in [from, to).
+	FindByClub(ctx context.Context, clubID string, from, to time.Time) ([]*entities.Booking, error)
+	// FindOpenByClub returns active and confirmed bookings at clubID
+	// ending after t, however long ago they started.
+	FindOpenByClub(ctx context.Context, clubID string, t time.Time) ([]*entities.Booking, error)
+	// FindEndedBetween returns bookings at any club ending in [from, to).
+	FindEndedBetween(ctx context.Context, from, to time.Time) ([]*entities.Booking, error)
+	// CountNoShows counts the user's bookings at clubID marked as no-shows
+	// that ended at or after since.
+	CountNoShows(ctx context.Context, userID, clubID string, since time.Time) (int, error)
 	Create(ctx context.Context, b *entities.Booking) error
 	Update(ctx context.Context, b *entities.Booking) error
+	// MarkNoShow sets only no_show on booking id, and only while it is
+	// still active or confirmed without a check-in. It reports whether it
+	// did.
+	MarkNoShow(ctx context.Context, id string) (bool, error)
+	// MarkPaid writes b's status and payment fields, and only while the
+	// stored booking is still active. It reports whether it did.
+	MarkPaid(ctx context.Context, b *entities.Booking) (bool, error)
 }
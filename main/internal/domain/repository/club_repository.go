@@ -3,13 +3,39 @@ package repository
 import (
 	"context"
 	"main/internal/domain/entities"
+	"time"
 )
 
 // ClubRepository defines persistence operations for Club.
 type ClubRepository interface {
 	FindAll(ctx context.Context) ([]*entities.Club, error)
 	FindByID(ctx context.Context, id string) (*entities.Club, error)
+	// Search returns a page of clubs matching f in f.SortBy order and the
+	// cursor of the next page. f.Near is ignored.
+	Search(ctx context.Context, f entities.ClubFilter) ([]*entities.Club, string, error)
+	// SearchNear returns a page of clubs matching f within f.Radius meters
+	// of f.Near in f.SortBy order, closest first by default, and the cursor
+	// of the next page.
+	SearchNear(ctx context.Context, f entities.ClubFilter) ([]*entities.ClubDistance, string, error)
+	// AdjustAvailablePCs adds delta to the club's free computer count.
+	AdjustAvailablePCs(ctx context.Context, id string, delta int) error
+	// RaiseGPUClass records that the club has a computer of the given class.
+	RaiseGPUClass(ctx context.Context, id string, class int) error
+	// SetGPUClass records the best GPU class among the club's computers.
+	SetGPUClass(ctx context.Context, id string, class int) error
 	Create(ctx context.Context, club *entities.Club) error
-	Update(ctx context.Context, club *entities.Club) error
-	Delete(ctx context.Context, id string) error
+	// Update writes only the named stored fields of club, or all of its
+	// details when none are named; the search counters are never written.
+	// It returns ErrClubNotFound for a club not in the listing.
+	Update(ctx context.Context, club *entities.Club, fields ...string) error
+	// Delete moves the club to an archive so it drops out of every listing
+	// and lookup; its computers and bookings are left in place.
+	Delete(ctx context.Context, club *entities.Club) error
+	// Restore moves an archived club back, returning ErrClubNotFound if it
+	// is not in the archive.
+	Restore(ctx context.Context, id string) (*entities.Club, error)
+	// FindDeletedBefore returns archived clubs deleted before t.
+	FindDeletedBefore(ctx context.Context, t time.Time) ([]*entities.Club, error)
+	// Purge removes a club from the archive for good.
+	Purge(ctx context.Context, id string) error
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"main/internal/domain/entities"
+	"time"
 )
 
 // ComputerRepository defines persistence operations for Computer.
@@ -10,6 +11,31 @@ type ComputerRepository interface {
 	FindAll(ctx context.Context) ([]*entities.Computer, error)
 	FindByID(ctx context.Context, id string) (*entities.Computer, error)
 	FindByClub(ctx context.Context, clubID string) ([]*entities.Computer, error)
+	// Search returns a page of computers matching f ordered by club and PC
+	// number, and the cursor of the next page.
+	Search(ctx context.Context, f entities.ComputerFilter) ([]*entities.Computer, string, error)
 	Create(ctx context.Context, comp *entities.Computer) error
+	// CreateBatch creates all computers or none. Every write, including
+	// Create and Update, fails with ErrDuplicatePCNumber if the PC number
+	// is already used in the computer's club.
+	CreateBatch(ctx context.Context, comps []*entities.Computer) error
 	Update(ctx context.Context, comp *entities.Computer) error
+	// FindAgentsSilentSince returns computers shown online whose agent has
+	// not reported since t.
+	FindAgentsSilentSince(ctx context.Context, t time.Time) ([]*entities.Computer, error)
+	// SaveAgentStatus writes only the agent field, so frequent heartbeats
+	// do not race with edits of the rest of the computer.
+	SaveAgentStatus(ctx context.Context, id string, st *entities.AgentStatus) error
+	// Delete moves the computer to an archive so it drops out of every
+	// listing while its history stays available.
+	Delete(ctx context.Context, comp *entities.Computer) error
+	// FindArchivedWithClub returns the computers archived along with
+	// clubID.
+	FindArchivedWithClub(ctx context.Context, clubID string) ([]*entities.Computer, error)
+	// Restore moves an archived computer back under its ID, failing with
+	// ErrDuplicatePCNumber if its number has been taken since.
+	Restore(ctx context.Context, comp *entities.Computer) error
+	// Purge removes an archived computer for good, along with its
+	// computer_numbers entry if it still holds one.
+	Purge(ctx context.Context, comp *entities.Computer) error
 }
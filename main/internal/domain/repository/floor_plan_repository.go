@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// FloorPlanRepository defines persistence operations for FloorPlan; a club
+// has at most one.
+type FloorPlanRepository interface {
+	// FindByClub returns ErrFloorPlanNotFound if the club has no plan.
+	FindByClub(ctx context.Context, clubID string) (*entities.FloorPlan, error)
+	Save(ctx context.Context, plan *entities.FloorPlan) error
+	Delete(ctx context.Context, clubID string) error
+}
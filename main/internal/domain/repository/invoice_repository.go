@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// InvoiceRepository defines persistence operations for Invoice.
+type InvoiceRepository interface {
+	FindByBooking(ctx context.Context, bookingID string) (*entities.Invoice, error)
+	// CreateForBooking stores inv under its booking, assigning the next
+	// sequential invoice number. If the booking already has an invoice it is
+	// left untouched and copied into inv.
+	CreateForBooking(ctx context.Context, inv *entities.Invoice) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"time"
+)
+
+// LoyaltyRepository defines persistence operations for loyalty points.
+type LoyaltyRepository interface {
+	// FindConfig returns the club's config, or a disabled one if none is stored.
+	FindConfig(ctx context.Context, clubID string) (*entities.LoyaltyConfig, error)
+	SaveConfig(ctx context.Context, cfg *entities.LoyaltyConfig) error
+	// FindAccount returns the user's account at clubID, empty if none is
+	// stored.
+	FindAccount(ctx context.Context, userID, clubID string) (*entities.LoyaltyAccount, error)
+	// FindAccounts returns the user's accounts at every club.
+	FindAccounts(ctx context.Context, userID string) ([]*entities.LoyaltyAccount, error)
+	FindTransactions(ctx context.Context, userID string) ([]*entities.LoyaltyTransaction, error)
+	// Credit appends a positive entry that becomes a new lot. A repeated call
+	// with the same key is a no-op.
+	Credit(ctx context.Context, key string, tx *entities.LoyaltyTransaction) error
+	// Debit appends a negative entry, consuming unexpired lots of tx.ClubID
+	// oldest-expiry first. With partial set it debits as much as the balance allows and
+	// updates tx.Points accordingly; otherwise it fails with
+	// ErrInsufficientPoints. A repeated call with the same key is a no-op.
+	Debit(ctx context.Context, key string, tx *entities.LoyaltyTransaction, partial bool) error
+	// FindExpiredLots returns lots that expired before now and still hold points.
+	FindExpiredLots(ctx context.Context, now time.Time) ([]*entities.LoyaltyTransaction, error)
+	// Expire writes off the remaining points of a lot.
+	Expire(ctx context.Context, lotID string) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// MembershipRepository defines persistence operations for MembershipPlan and Membership.
+type MembershipRepository interface {
+	FindPlansByClub(ctx context.Context, clubID string) ([]*entities.MembershipPlan, error)
+	FindPlanByID(ctx context.Context, id string) (*entities.MembershipPlan, error)
+	CreatePlan(ctx context.Context, p *entities.MembershipPlan) error
+	UpdatePlan(ctx context.Context, p *entities.MembershipPlan) error
+
+	FindByID(ctx context.Context, id string) (*entities.Membership, error)
+	FindByUser(ctx context.Context, userID string) ([]*entities.Membership, error)
+	FindByStripeSubscription(ctx context.Context, subscriptionID string) (*entities.Membership, error)
+	Create(ctx context.Context, m *entities.Membership) error
+	Update(ctx context.Context, m *entities.Membership) error
+	Delete(ctx context.Context, id string) error
+	// ConsumeHours atomically adds hours to HoursUsed, failing with
+	// ErrMembershipHoursExhausted if the plan's included hours would be exceeded.
+	ConsumeHours(ctx context.Context, id string, hours, limit float64) error
+	// ReturnHours subtracts hours from HoursUsed; a repeated call with the
+	// same non-empty key is a no-op.
+	ReturnHours(ctx context.Context, key, id string, hours float64) error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"time"
+)
+
+// PaymentLedgerRepository defines persistence operations for the payments
+// ledger and reconciliation reports.
+type PaymentLedgerRepository interface {
+	// Record appends e under key and sets its ID; a repeated call with the
+	// same key is a no-op that leaves e without an ID.
+	Record(ctx context.Context, key string, e *entities.PaymentLedgerEntry) error
+	FindByProviderRef(ctx context.Context, ref string) (*entities.PaymentLedgerEntry, error)
+	FindByBooking(ctx context.Context, bookingID string) ([]*entities.PaymentLedgerEntry, error)
+	FindByPeriod(ctx context.Context, from, to time.Time) ([]*entities.PaymentLedgerEntry, error)
+	FindByClub(ctx context.Context, clubID string, from, to time.Time) ([]*entities.PaymentLedgerEntry, error)
+
+	SaveReconciliation(ctx context.Context, r *entities.ReconciliationReport) error
+	FindReconciliation(ctx context.Context, id string) (*entities.ReconciliationReport, error)
+}
+
+// PaymentGateway exposes what the payment provider reports about money
+// movements, so the ledger can be checked against it.
+type PaymentGateway interface {
+	BalanceTransaction(ctx context.Context, id string) (*entities.ProviderTransaction, error)
+	BalanceTransactions(ctx context.Context, from, to time.Time) ([]*entities.ProviderTransaction, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// PromoCodeRepository defines persistence operations for PromoCode.
+type PromoCodeRepository interface {
+	FindAll(ctx context.Context) ([]*entities.PromoCode, error)
+	FindByID(ctx context.Context, id string) (*entities.PromoCode, error)
+	FindByCode(ctx context.Context, code string) (*entities.PromoCode, error)
+	CountUserRedemptions(ctx context.Context, promoID, userID string) (int, error)
+	Create(ctx context.Context, p *entities.PromoCode) error
+	Update(ctx context.Context, p *entities.PromoCode) error
+	Delete(ctx context.Context, id string) error
+	// Redeem atomically checks the global, per-user and first-booking limits
+	// and records the redemption. It returns ErrPromoCodeExhausted,
+	// ErrPromoCodeUserLimit or ErrPromoCodeFirstBooking when a limit has been
+	// reached.
+	Redeem(ctx context.Context, r *entities.PromoRedemption) error
+	// Release reverts a redemption, e.g. when the booking could not be saved
+	// or was cancelled. Releasing it again does nothing.
+	Release(ctx context.Context, redemptionID string) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// UserRepository defines persistence operations for User profiles.
+type UserRepository interface {
+	// FindByID returns ErrUserNotFound for a UID without a profile.
+	FindByID(ctx context.Context, id string) (*entities.User, error)
+	// FindByIDs returns the profiles that exist among ids, keyed by ID.
+	FindByIDs(ctx context.Context, ids []string) (map[string]*entities.User, error)
+	// Create stores u unless a profile with its ID exists, and returns the
+	// stored profile either way.
+	Create(ctx context.Context, u *entities.User) (*entities.User, error)
+	// Update writes only the named stored fields of u, so concurrent
+	// changes to its other fields survive.
+	Update(ctx context.Context, u *entities.User, fields ...string) error
+	// FindByVerificationStatus returns the profiles whose age verification
+	// has status, oldest request first.
+	FindByVerificationStatus(ctx context.Context, status string) ([]*entities.User, error)
+}
+
+// RoleStore reads and changes the role a user signs in with and, for
+// staff, the club they work at.
+type RoleStore interface {
+	Role(ctx context.Context, uid string) (role, clubID string, err error)
+	SetRole(ctx context.Context, uid, role, clubID string) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"main/internal/domain/entities"
+)
+
+// WalletRepository defines persistence operations for Wallet and its ledger.
+type WalletRepository interface {
+	FindByUser(ctx context.Context, userID string) (*entities.Wallet, error)
+	FindTransactions(ctx context.Context, userID string) ([]*entities.WalletTransaction, error)
+	// Apply atomically appends tx to the ledger and updates the wallet
+	// balance, setting tx.BalanceAfter. It returns ErrInsufficientFunds if the
+	// balance would become negative. When key is not empty it is used as the
+	// ledger entry ID and a repeated call with the same key is a no-op.
+	Apply(ctx context.Context, key string, tx *entities.WalletTransaction) error
+}
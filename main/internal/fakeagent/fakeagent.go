@@ -0,0 +1,118 @@
+// Package fakeagent plays the agent of one club PC against the API, for
+// trying the command queue without real hardware and for tests. It sends
+// heartbeats, long-polls for commands, logs them and acknowledges each one.
+package fakeagent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"main/internal/domain/entities"
+)
+
+// Agent is the fake agent of the computer its API key was issued for.
+type Agent struct {
+	API   string
+	Token string
+	// Fail is a command kind to acknowledge as failed.
+	Fail string
+	// Session is the booking unlocked on the PC, empty while it is locked.
+	Session string
+	Client  *http.Client
+
+	started time.Time
+	// done remembers handled commands, since a command can be delivered
+	// again if its acknowledgement was lost.
+	done map[string]bool
+}
+
+// New creates an Agent calling the API at api with the agent key token.
+func New(api, token string) *Agent {
+	return &Agent{API: api, Token: token, Client: http.DefaultClient, started: time.Now(), done: make(map[string]bool)}
+}
+
+// Heartbeat reports the PC as online.
+func (a *Agent) Heartbeat() error {
+	return a.call(http.MethodPost, "/agent/heartbeat", entities.Heartbeat{
+		SessionUser: a.Session,
+		CPUTempC:    55,
+		GPUTempC:    60,
+		UptimeSec:   int64(time.Since(a.started).Seconds()),
+	}, nil)
+}
+
+// Poll returns the due commands, waiting up to wait for one.
+func (a *Agent) Poll(wait time.Duration) ([]*entities.AgentCommand, error) {
+	path := "/agent/commands"
+	if wait > 0 {
+		path += "?wait=" + wait.String()
+	}
+	var cmds []*entities.AgentCommand
+	err := a.call(http.MethodGet, path, nil, &cmds)
+	return cmds, err
+}
+
+// Run carries out cmd once and acknowledges it.
+func (a *Agent) Run(cmd *entities.AgentCommand) error {
+	var ack struct {
+		Error string `json:"error"`
+	}
+	if !a.done[cmd.ID] {
+		log.Printf("command %s: %s %s (attempt %d)", cmd.ID, cmd.Kind, cmd.Message, cmd.Attempts)
+		switch {
+		case cmd.Kind == a.Fail:
+			ack.Error = "simulated failure"
+		case cmd.Kind == entities.CommandUnlock:
+			a.Session = cmd.BookingID
+		case cmd.Kind == entities.CommandLock:
+			a.Session = ""
+		}
+		a.done[cmd.ID] = true
+	}
+	return a.call(http.MethodPost, "/agent/commands/"+cmd.ID+"/ack", ack, nil)
+}
+
+// Step polls once and runs the commands it got, returning them.
+func (a *Agent) Step(wait time.Duration) ([]*entities.AgentCommand, error) {
+	cmds, err := a.Poll(wait)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range cmds {
+		if err := a.Run(cmd); err != nil {
+			return cmds, fmt.Errorf("ack %s: %w", cmd.ID, err)
+		}
+	}
+	return cmds, nil
+}
+
+func (a *Agent) call(method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, a.API+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,247 @@
+package fakeagent
+
+import (
+	"context"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"main/internal/interfaces/http/handler"
+	"main/internal/interfaces/http/middleware"
+)
+
+// memCommands keeps the command queue in memory.
+type memCommands struct {
+	mu   sync.Mutex
+	seq  int
+	cmds map[string]entities.AgentCommand
+}
+
+func (r *memCommands) FindByID(ctx context.Context, id string) (*entities.AgentCommand, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cmd, ok := r.cmds[id]
+	if !ok {
+		return nil, entities.ErrCommandNotFound
+	}
+	return &cmd, nil
+}
+
+func (r *memCommands) FindByComputer(ctx context.Context, computerID string, limit int) ([]*entities.AgentCommand, error) {
+	return r.find(func(c *entities.AgentCommand) bool { return c.ComputerID == computerID }), nil
+}
+
+func (r *memCommands) FindOpenByComputer(ctx context.Context, computerID string) ([]*entities.AgentCommand, error) {
+	return r.find(func(c *entities.AgentCommand) bool { return c.ComputerID == computerID && c.Open() }), nil
+}
+
+func (r *memCommands) FindOpenByBooking(ctx context.Context, bookingID string) ([]*entities.AgentCommand, error) {
+	return r.find(func(c *entities.AgentCommand) bool { return c.BookingID == bookingID && c.Open() }), nil
+}
+
+func (r *memCommands) FindOpenExpiredBefore(ctx context.Context, t time.Time) ([]*entities.AgentCommand, error) {
+	return r.find(func(c *entities.AgentCommand) bool { return c.Open() && c.ExpiresAt.Before(t) }), nil
+}
+
+func (r *memCommands) Create(ctx context.Context, cmd *entities.AgentCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	cmd.ID = "cmd" + strconv.Itoa(r.seq)
+	r.cmds[cmd.ID] = *cmd
+	return nil
+}
+
+func (r *memCommands) Update(ctx context.Context, cmd *entities.AgentCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cmds[cmd.ID] = *cmd
+	return nil
+}
+
+func (r *memCommands) Claim(ctx context.Context, cmd *entities.AgentCommand, status string, attempts int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur := r.cmds[cmd.ID]; cur.Status != status || cur.Attempts != attempts {
+		return false, nil
+	}
+	r.cmds[cmd.ID] = *cmd
+	return true, nil
+}
+
+func (r *memCommands) find(keep func(*entities.AgentCommand) bool) []*entities.AgentCommand {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*entities.AgentCommand
+	for _, c := range r.cmds {
+		c := c
+		if keep(&c) {
+			out = append(out, &c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].NotBefore.Before(out[j].NotBefore) })
+	return out
+}
+
+// memComputers serves the computers of one club.
+type memComputers struct {
+	repository.ComputerRepository
+	comps []*entities.Computer
+}
+
+func (r *memComputers) FindByClub(ctx context.Context, clubID string) ([]*entities.Computer, error) {
+	var out []*entities.Computer
+	for _, c := range r.comps {
+		if c.ClubID == clubID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *memComputers) FindByID(ctx context.Context, id string) (*entities.Computer, error) {
+	for _, c := range r.comps {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, entities.ErrComputerNotFound
+}
+
+func (r *memComputers) GetByID(ctx context.Context, id string) (*entities.Computer, error) {
+	return r.FindByID(ctx, id)
+}
+
+// agentKeys issues an agent key for every computer, named after it.
+type agentKeys struct{}
+
+func (agentKeys) Authenticate(ctx context.Context, raw string) (*entities.Principal, error) {
+	compID := raw[len(entities.APIKeyTag):]
+	return &entities.Principal{
+		Kind:       entities.PrincipalAPIKey,
+		ClubID:     "club1",
+		ComputerID: compID,
+		Scopes:     []string{entities.ScopeAgent},
+	}, nil
+}
+
+// serve runs the agent command routes over an in-memory queue.
+func serve(t *testing.T) (usecase.AgentCommandUseCase, *memCommands, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	repo := &memCommands{cmds: map[string]entities.AgentCommand{}}
+	comps := &memComputers{comps: []*entities.Computer{{ID: "pc1", ClubID: "club1", PCNumber: 1}}}
+	uc := usecase.NewAgentCommandUseCase(repo, comps)
+	commandH := handler.NewAgentCommandHandler(uc)
+
+	r := gin.New()
+	agent := r.Group("/agent", middleware.AuthMiddleware(nil, agentKeys{}), middleware.AgentMiddleware(comps))
+	agent.GET("/commands", commandH.PollCommands)
+	agent.POST("/commands/:id/ack", commandH.AckCommand)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return uc, repo, srv.URL
+}
+
+func TestAgentFollowsBookingSession(t *testing.T) {
+	uc, repo, api := serve(t)
+	ctx := context.Background()
+	now := time.Now()
+	b := &entities.Booking{
+		ID:          "b1",
+		ClubID:      "club1",
+		PCNumber:    1,
+		StartTime:   now.Add(-time.Minute),
+		EndTime:     now.Add(time.Hour),
+		CheckedInAt: now,
+	}
+	a := New(api, entities.APIKeyTag+"pc1")
+
+	if err := uc.StartSession(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	cmds, err := a.Step(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cmds) != 1 || cmds[0].Kind != entities.CommandUnlock {
+		t.Fatalf("got %+v, want the unlock only", cmds)
+	}
+	if a.Session != "b1" {
+		t.Errorf("Session = %q, want b1", a.Session)
+	}
+	if got, _ := repo.FindByID(ctx, cmds[0].ID); got.Status != entities.CommandAcked {
+		t.Errorf("unlock status = %s, want acked", got.Status)
+	}
+
+	// ending early drops the scheduled warning and lock for a lock now
+	if err := uc.EndSession(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if cmds, err = a.Step(0); err != nil {
+		t.Fatal(err)
+	}
+	if len(cmds) != 1 || cmds[0].Kind != entities.CommandLock {
+		t.Fatalf("got %+v, want a lock", cmds)
+	}
+	if a.Session != "" {
+		t.Errorf("Session = %q, want none", a.Session)
+	}
+	if open, _ := repo.FindOpenByBooking(ctx, "b1"); len(open) != 0 {
+		t.Errorf("%d commands still open", len(open))
+	}
+}
+
+func TestAgentReportsFailure(t *testing.T) {
+	uc, repo, api := serve(t)
+	ctx := context.Background()
+	sent, err := uc.Send(ctx, "staff1", "pc1", entities.CommandReboot, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := New(api, entities.APIKeyTag+"pc1")
+	a.Fail = entities.CommandReboot
+	if _, err := a.Step(0); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := repo.FindByID(ctx, sent.ID)
+	if got.Status != entities.CommandFailed || got.Error == "" {
+		t.Errorf("reboot = %s %q, want failed with the agent's error", got.Status, got.Error)
+	}
+}
+
+func TestConcurrentPollsDeliverOnce(t *testing.T) {
+	uc, _, api := serve(t)
+	if _, err := uc.Send(context.Background(), "staff1", "pc1", entities.CommandUnlock, ""); err != nil {
+		t.Fatal(err)
+	}
+	// a restarted agent can poll while its old connection is still open
+	var wg sync.WaitGroup
+	got := make([]int, 8)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cmds, err := New(api, entities.APIKeyTag+"pc1").Poll(0)
+			if err != nil {
+				t.Error(err)
+			}
+			got[i] = len(cmds)
+		}(i)
+	}
+	wg.Wait()
+	total := 0
+	for _, n := range got {
+		total += n
+	}
+	if total != 1 {
+		t.Errorf("command delivered %d times, want once", total)
+	}
+}
@@ -0,0 +1,245 @@
+// Package audit wraps repositories so every write they make is recorded in
+// the audit log, whichever use case or job makes it.
+package audit
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+)
+
+// Recorder logs a change of an entity; see usecase.AuditUseCase.
+type Recorder interface {
+	Record(ctx context.Context, action, entityType, entityID string, before, after interface{})
+}
+
+type clubs struct {
+	repository.ClubRepository
+	log Recorder
+}
+
+// Clubs records club creation, updates, deletion, restores and purges. Counter adjustments
+// kept in step with computers and bookings are not recorded.
+func Clubs(r repository.ClubRepository, log Recorder) repository.ClubRepository {
+	return &clubs{ClubRepository: r, log: log}
+}
+
+func (r *clubs) Create(ctx context.Context, c *entities.Club) error {
+	if err := r.ClubRepository.Create(ctx, c); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditCreate, entities.AuditClub, c.ID, nil, c)
+	return nil
+}
+
+func (r *clubs) Update(ctx context.Context, c *entities.Club, fields ...string) error {
+	before, _ := r.ClubRepository.FindByID(ctx, c.ID)
+	if err := r.ClubRepository.Update(ctx, c, fields...); err != nil {
+		return err
+	}
+	// only some fields were written, so record what is stored now
+	after, _ := r.ClubRepository.FindByID(ctx, c.ID)
+	r.log.Record(ctx, entities.AuditUpdate, entities.AuditClub, c.ID, before, after)
+	return nil
+}
+
+func (r *clubs) Delete(ctx context.Context, c *entities.Club) error {
+	if err := r.ClubRepository.Delete(ctx, c); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditDelete, entities.AuditClub, c.ID, c, nil)
+	return nil
+}
+
+func (r *clubs) Restore(ctx context.Context, id string) (*entities.Club, error) {
+	c, err := r.ClubRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.log.Record(ctx, entities.AuditRestore, entities.AuditClub, id, nil, c)
+	return c, nil
+}
+
+func (r *clubs) Purge(ctx context.Context, id string) error {
+	if err := r.ClubRepository.Purge(ctx, id); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditPurge, entities.AuditClub, id, nil, nil)
+	return nil
+}
+
+type computers struct {
+	repository.ComputerRepository
+	log Recorder
+}
+
+// Computers records computer creation, updates and deletion. Agent
+// heartbeats are not recorded.
+func Computers(r repository.ComputerRepository, log Recorder) repository.ComputerRepository {
+	return &computers{ComputerRepository: r, log: log}
+}
+
+func (r *computers) Create(ctx context.Context, c *entities.Computer) error {
+	return r.CreateBatch(ctx, []*entities.Computer{c})
+}
+
+func (r *computers) CreateBatch(ctx context.Context, comps []*entities.Computer) error {
+	if err := r.ComputerRepository.CreateBatch(ctx, comps); err != nil {
+		return err
+	}
+	for _, c := range comps {
+		r.log.Record(ctx, entities.AuditCreate, entities.AuditComputer, c.ID, nil, c)
+	}
+	return nil
+}
+
+func (r *computers) Update(ctx context.Context, c *entities.Computer) error {
+	before, _ := r.ComputerRepository.FindByID(ctx, c.ID)
+	if err := r.ComputerRepository.Update(ctx, c); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditUpdate, entities.AuditComputer, c.ID, before, c)
+	return nil
+}
+
+func (r *computers) Delete(ctx context.Context, c *entities.Computer) error {
+	if err := r.ComputerRepository.Delete(ctx, c); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditDelete, entities.AuditComputer, c.ID, c, nil)
+	return nil
+}
+
+func (r *computers) Restore(ctx context.Context, c *entities.Computer) error {
+	if err := r.ComputerRepository.Restore(ctx, c); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditRestore, entities.AuditComputer, c.ID, nil, c)
+	return nil
+}
+
+func (r *computers) Purge(ctx context.Context, c *entities.Computer) error {
+	if err := r.ComputerRepository.Purge(ctx, c); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditPurge, entities.AuditComputer, c.ID, nil, nil)
+	return nil
+}
+
+type bookings struct {
+	repository.BookingRepository
+	log Recorder
+}
+
+// Bookings records booking creation and every status or payment change.
+func Bookings(r repository.BookingRepository, log Recorder) repository.BookingRepository {
+	return &bookings{BookingRepository: r, log: log}
+}
+
+func (r *bookings) Create(ctx context.Context, b *entities.Booking) error {
+	if err := r.BookingRepository.Create(ctx, b); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditCreate, entities.AuditBooking, b.ID, nil, b)
+	return nil
+}
+
+func (r *bookings) Update(ctx context.Context, b *entities.Booking) error {
+	before, _ := r.BookingRepository.FindByID(ctx, b.ID)
+	if err := r.BookingRepository.Update(ctx, b); err != nil {
+		return err
+	}
+	r.log.Record(ctx, entities.AuditUpdate, entities.AuditBooking, b.ID, before, b)
+	return nil
+}
+
+func (r *bookings) MarkNoShow(ctx context.Context, id string) (bool, error) {
+	before, _ := r.BookingRepository.FindByID(ctx, id)
+	marked, err := r.BookingRepository.MarkNoShow(ctx, id)
+	if err != nil || !marked {
+		return marked, err
+	}
+	after, _ := r.BookingRepository.FindByID(ctx, id)
+	r.log.Record(ctx, entities.AuditUpdate, entities.AuditBooking, id, before, after)
+	return true, nil
+}
+
+func (r *bookings) MarkPaid(ctx context.Context, b *entities.Booking) (bool, error) {
+	before, _ := r.BookingRepository.FindByID(ctx, b.ID)
+	paid, err := r.BookingRepository.MarkPaid(ctx, b)
+	if err != nil || !paid {
+		return paid, err
+	}
+	r.log.Record(ctx, entities.AuditUpdate, entities.AuditBooking, b.ID, before, b)
+	return true, nil
+}
+
+type payments struct {
+	repository.PaymentLedgerRepository
+	log Recorder
+}
+
+// Payments records every payment ledger entry.
+func Payments(r repository.PaymentLedgerRepository, log Recorder) repository.PaymentLedgerRepository {
+	return &payments{PaymentLedgerRepository: r, log: log}
+}
+
+func (r *payments) Record(ctx context.Context, key string, e *entities.PaymentLedgerEntry) error {
+	if err := r.PaymentLedgerRepository.Record(ctx, key, e); err != nil {
+		return err
+	}
+	// a repeated key leaves e unwritten and without an ID
+	if e.ID != "" {
+		r.log.Record(ctx, entities.AuditCreate, entities.AuditPayment, e.ID, nil, e)
+	}
+	return nil
+}
+
+type wallets struct {
+	repository.WalletRepository
+	log Recorder
+}
+
+// Wallets records every wallet transaction.
+func Wallets(r repository.WalletRepository, log Recorder) repository.WalletRepository {
+	return &wallets{WalletRepository: r, log: log}
+}
+
+func (r *wallets) Apply(ctx context.Context, key string, tx *entities.WalletTransaction) error {
+	if err := r.WalletRepository.Apply(ctx, key, tx); err != nil {
+		return err
+	}
+	// a repeated key leaves tx unwritten and without an ID
+	if tx.ID != "" {
+		r.log.Record(ctx, entities.AuditCreate, entities.AuditWallet, tx.ID, nil, tx)
+	}
+	return nil
+}
+
+type roles struct {
+	repository.RoleStore
+	log Recorder
+}
+
+// roleChange is the audited state of a role assignment.
+type roleChange struct {
+	Role   string `firestore:"role"`
+	ClubID string `firestore:"club_id"`
+}
+
+// Roles records every role change.
+func Roles(s repository.RoleStore, log Recorder) repository.RoleStore {
+	return &roles{RoleStore: s, log: log}
+}
+
+func (s *roles) SetRole(ctx context.Context, uid, role, clubID string) error {
+	before, beforeClub, err := s.RoleStore.Role(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := s.RoleStore.SetRole(ctx, uid, role, clubID); err != nil {
+		return err
+	}
+	s.log.Record(ctx, entities.AuditUpdate, entities.AuditRole, uid, roleChange{before, beforeClub}, roleChange{role, clubID})
+	return nil
+}
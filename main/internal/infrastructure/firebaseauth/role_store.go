@@ -0,0 +1,61 @@
+package firebaseauth
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+// roleStore implements RoleStore with the "role" and "club_id" custom
+// claims that AuthMiddleware reads from ID tokens.
+type roleStore struct {
+	client *auth.Client
+}
+
+// NewRoleStore creates a RoleStore backed by Firebase Auth custom claims.
+func NewRoleStore(c *auth.Client) repository.RoleStore {
+	return &roleStore{client: c}
+}
+
+func (s *roleStore) Role(ctx context.Context, uid string) (string, string, error) {
+	u, err := s.client.GetUser(ctx, uid)
+	if auth.IsUserNotFound(err) {
+		return "", "", entities.ErrUserNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+	role, _ := u.CustomClaims["role"].(string)
+	clubID, _ := u.CustomClaims["club_id"].(string)
+	return role, clubID, nil
+}
+
+// SetRole keeps the user's other custom claims. An empty role or clubID
+// removes the claim. The new role applies once the user's ID token is
+// refreshed.
+func (s *roleStore) SetRole(ctx context.Context, uid, role, clubID string) error {
+	u, err := s.client.GetUser(ctx, uid)
+	if auth.IsUserNotFound(err) {
+		return entities.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	claims := make(map[string]interface{}, len(u.CustomClaims)+1)
+	for k, v := range u.CustomClaims {
+		claims[k] = v
+	}
+	if role == "" {
+		delete(claims, "role")
+	} else {
+		claims["role"] = role
+	}
+	if clubID == "" {
+		delete(claims, "club_id")
+	} else {
+		claims["club_id"] = clubID
+	}
+	return s.client.SetCustomUserClaims(ctx, uid, claims)
+}
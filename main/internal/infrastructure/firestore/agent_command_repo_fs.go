@@ -0,0 +1,112 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openCommandStatuses are the statuses of commands still in the queue.
+var openCommandStatuses = []string{entities.CommandPending, entities.CommandDelivered}
+
+// agentCommandRepoFS implements AgentCommandRepository using Firestore as backend.
+type agentCommandRepoFS struct {
+	client *firestore.Client
+}
+
+// NewAgentCommandRepoFS creates a Firestore-based implementation of AgentCommandRepository.
+func NewAgentCommandRepoFS(c *firestore.Client) repository.AgentCommandRepository {
+	return &agentCommandRepoFS{client: c}
+}
+
+func (r *agentCommandRepoFS) FindByID(ctx context.Context, id string) (*entities.AgentCommand, error) {
+	doc, err := r.client.Collection("agent_commands").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrCommandNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cmd entities.AgentCommand
+	doc.DataTo(&cmd)
+	cmd.ID = doc.Ref.ID
+	return &cmd, nil
+}
+
+func (r *agentCommandRepoFS) FindByComputer(ctx context.Context, computerID string, limit int) ([]*entities.AgentCommand, error) {
+	return r.query(ctx, r.client.Collection("agent_commands").
+		Where("computer_id", "==", computerID).
+		OrderBy("created_at", firestore.Desc).
+		Limit(limit))
+}
+
+func (r *agentCommandRepoFS) FindOpenByComputer(ctx context.Context, computerID string) ([]*entities.AgentCommand, error) {
+	return r.query(ctx, r.client.Collection("agent_commands").
+		Where("computer_id", "==", computerID).
+		Where("status", "in", openCommandStatuses).
+		OrderBy("not_before", firestore.Asc))
+}
+
+func (r *agentCommandRepoFS) FindOpenByBooking(ctx context.Context, bookingID string) ([]*entities.AgentCommand, error) {
+	return r.query(ctx, r.client.Collection("agent_commands").
+		Where("booking_id", "==", bookingID).
+		Where("status", "in", openCommandStatuses))
+}
+
+func (r *agentCommandRepoFS) FindOpenExpiredBefore(ctx context.Context, t time.Time) ([]*entities.AgentCommand, error) {
+	return r.query(ctx, r.client.Collection("agent_commands").
+		Where("status", "in", openCommandStatuses).
+		Where("expires_at", "<", t))
+}
+
+func (r *agentCommandRepoFS) query(ctx context.Context, q firestore.Query) ([]*entities.AgentCommand, error) {
+	docs, err := q.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.AgentCommand
+	for _, doc := range docs {
+		var cmd entities.AgentCommand
+		doc.DataTo(&cmd)
+		cmd.ID = doc.Ref.ID
+		out = append(out, &cmd)
+	}
+	return out, nil
+}
+
+func (r *agentCommandRepoFS) Create(ctx context.Context, cmd *entities.AgentCommand) error {
+	ref := r.client.Collection("agent_commands").NewDoc()
+	cmd.ID = ref.ID
+	_, err := ref.Set(ctx, cmd)
+	return err
+}
+
+func (r *agentCommandRepoFS) Update(ctx context.Context, cmd *entities.AgentCommand) error {
+	_, err := r.client.Collection("agent_commands").Doc(cmd.ID).Set(ctx, cmd)
+	return err
+}
+
+func (r *agentCommandRepoFS) Claim(ctx context.Context, cmd *entities.AgentCommand, status string, attempts int) (bool, error) {
+	ref := r.client.Collection("agent_commands").Doc(cmd.ID)
+	claimed := false
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		claimed = false
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var cur entities.AgentCommand
+		doc.DataTo(&cur)
+		if cur.Status != status || cur.Attempts != attempts {
+			return nil
+		}
+		claimed = true
+		return tx.Set(ref, cmd)
+	})
+	return claimed, err
+}
@@ -0,0 +1,74 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// alertRepoFS implements AlertRepository using Firestore as backend.
+type alertRepoFS struct {
+	client *firestore.Client
+}
+
+// NewAlertRepoFS creates a Firestore-based implementation of AlertRepository.
+func NewAlertRepoFS(c *firestore.Client) repository.AlertRepository {
+	return &alertRepoFS{client: c}
+}
+
+func (r *alertRepoFS) FindByID(ctx context.Context, id string) (*entities.Alert, error) {
+	doc, err := r.client.Collection("alerts").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrAlertNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a entities.Alert
+	doc.DataTo(&a)
+	a.ID = doc.Ref.ID
+	return &a, nil
+}
+
+func (r *alertRepoFS) FindOpenByClub(ctx context.Context, clubID string) ([]*entities.Alert, error) {
+	return r.findOpen(ctx, "club_id", clubID)
+}
+
+func (r *alertRepoFS) FindOpenByComputer(ctx context.Context, computerID string) ([]*entities.Alert, error) {
+	return r.findOpen(ctx, "computer_id", computerID)
+}
+
+func (r *alertRepoFS) findOpen(ctx context.Context, field, value string) ([]*entities.Alert, error) {
+	docs, err := r.client.Collection("alerts").
+		Where(field, "==", value).
+		Where("open", "==", true).
+		OrderBy("created_at", firestore.Desc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Alert
+	for _, doc := range docs {
+		var a entities.Alert
+		doc.DataTo(&a)
+		a.ID = doc.Ref.ID
+		out = append(out, &a)
+	}
+	return out, nil
+}
+
+func (r *alertRepoFS) Create(ctx context.Context, a *entities.Alert) error {
+	ref := r.client.Collection("alerts").NewDoc()
+	a.ID = ref.ID
+	_, err := ref.Set(ctx, a)
+	return err
+}
+
+func (r *alertRepoFS) Update(ctx context.Context, a *entities.Alert) error {
+	_, err := r.client.Collection("alerts").Doc(a.ID).Set(ctx, a)
+	return err
+}
@@ -0,0 +1,91 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyRepoFS implements APIKeyRepository using Firestore as backend.
+type apiKeyRepoFS struct {
+	client *firestore.Client
+}
+
+// NewAPIKeyRepoFS creates a Firestore-based implementation of APIKeyRepository.
+func NewAPIKeyRepoFS(c *firestore.Client) repository.APIKeyRepository {
+	return &apiKeyRepoFS{client: c}
+}
+
+func (r *apiKeyRepoFS) FindByID(ctx context.Context, id string) (*entities.APIKey, error) {
+	doc, err := r.client.Collection("api_keys").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var k entities.APIKey
+	doc.DataTo(&k)
+	k.ID = doc.Ref.ID
+	return &k, nil
+}
+
+func (r *apiKeyRepoFS) FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	list, err := r.query(ctx, r.client.Collection("api_keys").Where("prefix", "==", prefix).Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, entities.ErrAPIKeyNotFound
+	}
+	return list[0], nil
+}
+
+func (r *apiKeyRepoFS) FindByClub(ctx context.Context, clubID string) ([]*entities.APIKey, error) {
+	return r.query(ctx, r.client.Collection("api_keys").
+		Where("club_id", "==", clubID).
+		OrderBy("created_at", firestore.Desc))
+}
+
+func (r *apiKeyRepoFS) FindByComputer(ctx context.Context, computerID string) ([]*entities.APIKey, error) {
+	return r.query(ctx, r.client.Collection("api_keys").Where("computer_id", "==", computerID))
+}
+
+func (r *apiKeyRepoFS) query(ctx context.Context, q firestore.Query) ([]*entities.APIKey, error) {
+	docs, err := q.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.APIKey
+	for _, doc := range docs {
+		var k entities.APIKey
+		doc.DataTo(&k)
+		k.ID = doc.Ref.ID
+		out = append(out, &k)
+	}
+	return out, nil
+}
+
+func (r *apiKeyRepoFS) Create(ctx context.Context, k *entities.APIKey) error {
+	ref := r.client.Collection("api_keys").NewDoc()
+	k.ID = ref.ID
+	_, err := ref.Set(ctx, k)
+	return err
+}
+
+func (r *apiKeyRepoFS) Update(ctx context.Context, k *entities.APIKey) error {
+	_, err := r.client.Collection("api_keys").Doc(k.ID).Set(ctx, k)
+	return err
+}
+
+func (r *apiKeyRepoFS) Touch(ctx context.Context, id string, t time.Time) error {
+	_, err := r.client.Collection("api_keys").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "last_used_at", Value: t},
+	})
+	return err
+}
@@ -0,0 +1,99 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+)
+
+// purgeBatch bounds the entries one DeleteBefore round reads.
+const purgeBatch = 500
+
+// auditRepoFS implements AuditRepository using Firestore as backend.
+type auditRepoFS struct {
+	client *firestore.Client
+}
+
+// NewAuditRepoFS creates a Firestore-based implementation of AuditRepository.
+func NewAuditRepoFS(c *firestore.Client) repository.AuditRepository {
+	return &auditRepoFS{client: c}
+}
+
+func (r *auditRepoFS) Create(ctx context.Context, e *entities.AuditEntry) error {
+	ref := r.client.Collection("audit_log").NewDoc()
+	e.ID = ref.ID
+	_, err := ref.Create(ctx, e)
+	return err
+}
+
+func (r *auditRepoFS) Search(ctx context.Context, f entities.AuditFilter) ([]*entities.AuditEntry, string, error) {
+	col := r.client.Collection("audit_log")
+	q := col.Query
+	if f.ActorUID != "" {
+		q = q.Where("actor.uid", "==", f.ActorUID)
+	}
+	if f.KeyID != "" {
+		q = q.Where("actor.key_id", "==", f.KeyID)
+	}
+	if f.Action != "" {
+		q = q.Where("action", "==", f.Action)
+	}
+	if f.EntityType != "" {
+		q = q.Where("entity_type", "==", f.EntityType)
+	}
+	if f.EntityID != "" {
+		q = q.Where("entity_id", "==", f.EntityID)
+	}
+	if !f.From.IsZero() {
+		q = q.Where("at", ">=", f.From)
+	}
+	if !f.To.IsZero() {
+		q = q.Where("at", "<", f.To)
+	}
+	q = q.OrderBy("at", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Desc)
+	docs, next, err := page(ctx, col, q, f.Cursor, f.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	var out []*entities.AuditEntry
+	for _, doc := range docs {
+		var e entities.AuditEntry
+		doc.DataTo(&e)
+		e.ID = doc.Ref.ID
+		out = append(out, &e)
+	}
+	return out, next, nil
+}
+
+func (r *auditRepoFS) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	q := r.client.Collection("audit_log").Where("at", "<", t).Limit(purgeBatch)
+	total := 0
+	for {
+		docs, err := q.Documents(ctx).GetAll()
+		if err != nil || len(docs) == 0 {
+			return total, err
+		}
+		bw := r.client.BulkWriter(ctx)
+		jobs := make([]*firestore.BulkWriterJob, 0, len(docs))
+		for _, doc := range docs {
+			job, err := bw.Delete(doc.Ref)
+			if err != nil {
+				bw.End()
+				return total, err
+			}
+			jobs = append(jobs, job)
+		}
+		bw.End()
+		for _, job := range jobs {
+			if _, err := job.Results(); err != nil {
+				return total, err
+			}
+			total++
+		}
+		if len(docs) < purgeBatch {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// banRepoFS implements BanRepository using Firestore as backend.
+type banRepoFS struct {
+	client *firestore.Client
+}
+
+// NewBanRepoFS creates a Firestore-based implementation of BanRepository.
+func NewBanRepoFS(c *firestore.Client) repository.BanRepository {
+	return &banRepoFS{client: c}
+}
+
+func (r *banRepoFS) FindByID(ctx context.Context, id string) (*entities.Ban, error) {
+	doc, err := r.client.Collection("bans").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrBanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var b entities.Ban
+	doc.DataTo(&b)
+	b.ID = doc.Ref.ID
+	return &b, nil
+}
+
+func (r *banRepoFS) FindByUser(ctx context.Context, userID string) ([]*entities.Ban, error) {
+	return r.find(ctx, "user_id", userID)
+}
+
+func (r *banRepoFS) FindByClub(ctx context.Context, clubID string) ([]*entities.Ban, error) {
+	return r.find(ctx, "club_id", clubID)
+}
+
+func (r *banRepoFS) find(ctx context.Context, field, value string) ([]*entities.Ban, error) {
+	docs, err := r.client.Collection("bans").
+		Where(field, "==", value).
+		OrderBy("created_at", firestore.Desc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Ban
+	for _, doc := range docs {
+		var b entities.Ban
+		doc.DataTo(&b)
+		b.ID = doc.Ref.ID
+		out = append(out, &b)
+	}
+	return out, nil
+}
+
+func (r *banRepoFS) Create(ctx context.Context, b *entities.Ban) error {
+	ref := r.client.Collection("bans").NewDoc()
+	b.ID = ref.ID
+	_, err := ref.Set(ctx, b)
+	return err
+}
+
+func (r *banRepoFS) Update(ctx context.Context, b *entities.Ban) error {
+	_, err := r.client.Collection("bans").Doc(b.ID).Set(ctx, b)
+	return err
+}
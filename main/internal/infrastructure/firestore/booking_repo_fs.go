@@ -5,6 +5,7 @@ import (
 	"context"
 	"main/internal/domain/entities"
 	"main/internal/domain/repository"
+	"time"
 )
 
 // bookingRepoFS implements BookingRepository using Firestore as backend.
@@ -44,6 +45,94 @@ func (r *bookingRepoFS) FindByID(ctx context.Context, id string) (*entities.Book
 	return &b, nil
 }
 
+// heldOrPaid are the statuses of bookings that count as a user's bookings;
+// cancelled, expired and refunded ones do not.
+var heldOrPaid = []string{entities.BookingActive, entities.BookingConfirmed, entities.BookingCompleted}
+
+// userBookings selects the user's held or paid bookings.
+func userBookings(c *firestore.Client, userID string) firestore.Query {
+	return c.Collection("bookings").
+		Where("user_id", "==", userID).
+		Where("status", "in", heldOrPaid)
+}
+
+func (r *bookingRepoFS) CountByUser(ctx context.Context, userID string) (int, error) {
+	docs, err := userBookings(r.client, userID).Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+func (r *bookingRepoFS) FindByClub(ctx context.Context, clubID string, from, to time.Time) ([]*entities.Booking, error) {
+	docs, err := r.client.Collection("bookings").
+		Where("club_id", "==", clubID).
+		Where("start_time", ">=", from).
+		Where("start_time", "<", to).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Booking
+	for _, doc := range docs {
+		var b entities.Booking
+		doc.DataTo(&b)
+		b.ID = doc.Ref.ID
+		out = append(out, &b)
+	}
+	return out, nil
+}
+
+func (r *bookingRepoFS) FindOpenByClub(ctx context.Context, clubID string, t time.Time) ([]*entities.Booking, error) {
+	docs, err := r.client.Collection("bookings").
+		Where("club_id", "==", clubID).
+		Where("status", "in", []string{entities.BookingActive, entities.BookingConfirmed}).
+		Where("end_time", ">", t).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Booking
+	for _, doc := range docs {
+		var b entities.Booking
+		doc.DataTo(&b)
+		b.ID = doc.Ref.ID
+		out = append(out, &b)
+	}
+	return out, nil
+}
+
+func (r *bookingRepoFS) FindEndedBetween(ctx context.Context, from, to time.Time) ([]*entities.Booking, error) {
+	docs, err := r.client.Collection("bookings").
+		Where("end_time", ">=", from).
+		Where("end_time", "<", to).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Booking
+	for _, doc := range docs {
+		var b entities.Booking
+		doc.DataTo(&b)
+		b.ID = doc.Ref.ID
+		out = append(out, &b)
+	}
+	return out, nil
+}
+
+func (r *bookingRepoFS) CountNoShows(ctx context.Context, userID, clubID string, since time.Time) (int, error) {
+	docs, err := r.client.Collection("bookings").
+		Where("user_id", "==", userID).
+		Where("club_id", "==", clubID).
+		Where("no_show", "==", true).
+		Where("end_time", ">=", since).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
 func (r *bookingRepoFS) Create(ctx context.Context, b *entities.Booking) error {
 	ref := r.client.Collection("bookings").NewDoc()
 	b.ID = ref.ID
@@ -55,3 +144,52 @@ func (r *bookingRepoFS) Update(ctx context.Context, b *entities.Booking) error {
 	_, err := r.client.Collection("bookings").Doc(b.ID).Set(ctx, b)
 	return err
 }
+
+func (r *bookingRepoFS) MarkNoShow(ctx context.Context, id string) (bool, error) {
+	ref := r.client.Collection("bookings").Doc(id)
+	marked := false
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		marked = false
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var b entities.Booking
+		doc.DataTo(&b)
+		if b.NoShow || !b.CheckedInAt.IsZero() ||
+			(b.Status != entities.BookingConfirmed && b.Status != entities.BookingActive) {
+			return nil
+		}
+		marked = true
+		return tx.Update(ref, []firestore.Update{{Path: "no_show", Value: true}})
+	})
+	return marked, err
+}
+
+func (r *bookingRepoFS) MarkPaid(ctx context.Context, b *entities.Booking) (bool, error) {
+	ref := r.client.Collection("bookings").Doc(b.ID)
+	paid := false
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		paid = false
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var cur entities.Booking
+		doc.DataTo(&cur)
+		if cur.Status != entities.BookingActive {
+			return nil
+		}
+		paid = true
+		return tx.Update(ref, []firestore.Update{
+			{Path: "status", Value: b.Status},
+			{Path: "payment_method", Value: b.PaymentMethod},
+			{Path: "payment_ref", Value: b.PaymentRef},
+			{Path: "paid_by", Value: b.PaidBy},
+			{Path: "paid_at", Value: b.PaidAt},
+			{Path: "intent_id", Value: b.IntentID},
+			{Path: "checkout_id", Value: b.CheckoutID},
+		})
+	})
+	return paid, err
+}
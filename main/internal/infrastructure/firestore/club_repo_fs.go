@@ -3,8 +3,16 @@ package firestore
 import (
 	"cloud.google.com/go/firestore"
 	"context"
+	"fmt"
 	"main/internal/domain/entities"
+	"main/internal/domain/geo"
 	"main/internal/domain/repository"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // clubRepoFS implements ClubRepository using Firestore as backend.
@@ -12,6 +20,22 @@ type clubRepoFS struct {
 	client *firestore.Client
 }
 
+// clubDoc is the stored form of a club. Firestore allows a single
+// array-contains filter per query, so amenities are also indexed as a map
+// that can be matched with one equality filter each.
+type clubDoc struct {
+	*entities.Club
+	AmenityIndex map[string]bool `firestore:"amenity_index"`
+}
+
+func newClubDoc(c *entities.Club) *clubDoc {
+	idx := make(map[string]bool, len(c.Amenities))
+	for _, a := range c.Amenities {
+		idx[a] = true
+	}
+	return &clubDoc{Club: c, AmenityIndex: idx}
+}
+
 // NewClubRepoFS creates a Firestore-based implementation of ClubRepository.
 func NewClubRepoFS(c *firestore.Client) repository.ClubRepository {
 	return &clubRepoFS{client: c}
@@ -22,18 +46,14 @@ func (r *clubRepoFS) FindAll(ctx context.Context) ([]*entities.Club, error) {
 	if err != nil {
 		return nil, err
 	}
-	var out []*entities.Club
-	for _, doc := range docs {
-		var c entities.Club
-		doc.DataTo(&c)
-		c.ID = doc.Ref.ID
-		out = append(out, &c)
-	}
-	return out, nil
+	return clubsFromDocs(docs), nil
 }
 
 func (r *clubRepoFS) FindByID(ctx context.Context, id string) (*entities.Club, error) {
 	doc, err := r.client.Collection("clubs").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrClubNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -43,19 +63,274 @@ func (r *clubRepoFS) FindByID(ctx context.Context, id string) (*entities.Club, e
 	return &c, nil
 }
 
+func (r *clubRepoFS) Search(ctx context.Context, f entities.ClubFilter) ([]*entities.Club, string, error) {
+	col := r.client.Collection("clubs")
+	q := r.filter(col.Query, f)
+	dir := firestore.Asc
+	if f.Desc {
+		dir = firestore.Desc
+	}
+	switch f.SortBy {
+	case entities.ClubSortPrice:
+		q = q.OrderBy("price_per_hour", dir)
+	case entities.ClubSortRating:
+		q = q.OrderBy("rating", dir)
+	default:
+		q = q.OrderBy("name", dir)
+	}
+	// document ID breaks ties so the cursor position is unambiguous
+	q = q.OrderBy(firestore.DocumentID, dir)
+	var keep func(*firestore.DocumentSnapshot) bool
+	if f.OpenNow {
+		now := time.Now()
+		keep = func(doc *firestore.DocumentSnapshot) bool {
+			var c entities.Club
+			doc.DataTo(&c)
+			return openAt(&c, now)
+		}
+	}
+	docs, next, err := pageWhere(ctx, col, q, f.Cursor, f.Limit, keep)
+	if err != nil {
+		return nil, "", err
+	}
+	return clubsFromDocs(docs), next, nil
+}
+
+// SearchNear loads every candidate in the geohash cells covering the
+// circle, which the radius cap keeps few, then sorts and pages them; its
+// cursor is an offset.
+func (r *clubRepoFS) SearchNear(ctx context.Context, f entities.ClubFilter) ([]*entities.ClubDistance, string, error) {
+	offset := 0
+	if f.Cursor != "" {
+		n, err := strconv.Atoi(f.Cursor)
+		if err != nil || n < 0 {
+			return nil, "", entities.ErrInvalidCursor
+		}
+		offset = n
+	}
+	center := *f.Near
+	now := time.Now()
+	seen := map[string]bool{}
+	items := []*entities.ClubDistance{}
+	for _, rg := range geo.CoveringRanges(center, f.Radius) {
+		docs, err := r.filter(r.client.Collection("clubs").Query, f).
+			Where("location.geohash", ">=", rg.Start).
+			Where("location.geohash", "<=", rg.End).
+			Documents(ctx).GetAll()
+		if err != nil {
+			return nil, "", err
+		}
+		for _, c := range clubsFromDocs(docs) {
+			if seen[c.ID] || c.Location == nil || (f.OpenNow && !openAt(c, now)) {
+				continue
+			}
+			seen[c.ID] = true
+			// geohash cells overshoot the circle, so filter by exact distance
+			if d := geo.Distance(center, c.Location.Point()); d <= f.Radius {
+				items = append(items, &entities.ClubDistance{Club: c, Distance: d})
+			}
+		}
+	}
+	key := func(c *entities.ClubDistance) float64 {
+		switch f.SortBy {
+		case entities.ClubSortPrice:
+			return c.PricePerHour
+		case entities.ClubSortRating:
+			return c.Rating
+		}
+		return c.Distance
+	}
+	// ID breaks ties so pages are stable between requests
+	sort.Slice(items, func(a, b int) bool {
+		ka, kb := key(items[a]), key(items[b])
+		if ka != kb {
+			return (ka < kb) != f.Desc
+		}
+		return items[a].ID < items[b].ID
+	})
+	if offset >= len(items) {
+		return []*entities.ClubDistance{}, "", nil
+	}
+	end := min(offset+f.Limit, len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next, nil
+}
+
+// openAt reports whether the club's schedule has it open at t. Schedules
+// cannot be expressed as a query, so open_now is checked on each candidate.
+func openAt(c *entities.Club, t time.Time) bool {
+	return c.Hours.IsOpenAt(t.In(c.TZ()))
+}
+
+// filter applies every constraint of f that a query can express.
+func (r *clubRepoFS) filter(q firestore.Query, f entities.ClubFilter) firestore.Query {
+	if f.City != "" {
+		q = q.Where("city", "==", f.City)
+	}
+	for _, a := range f.Amenities {
+		q = q.WherePath(firestore.FieldPath{"amenity_index", a}, "==", true)
+	}
+	if f.MinPrice > 0 {
+		q = q.Where("price_per_hour", ">=", f.MinPrice)
+	}
+	if f.MaxPrice > 0 {
+		q = q.Where("price_per_hour", "<=", f.MaxPrice)
+	}
+	if f.HasFreePCs {
+		q = q.Where("available_pcs", ">", 0)
+	}
+	if f.MinGPUClass > 0 {
+		q = q.Where("max_gpu_class", ">=", f.MinGPUClass)
+	}
+	return q
+}
+
+func (r *clubRepoFS) AdjustAvailablePCs(ctx context.Context, id string, delta int) error {
+	_, err := r.client.Collection("clubs").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "available_pcs", Value: firestore.Increment(delta)},
+	})
+	return err
+}
+
+func (r *clubRepoFS) RaiseGPUClass(ctx context.Context, id string, class int) error {
+	ref := r.client.Collection("clubs").Doc(id)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var c entities.Club
+		doc.DataTo(&c)
+		if class <= c.MaxGPUClass {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{{Path: "max_gpu_class", Value: class}})
+	})
+}
+
+func (r *clubRepoFS) SetGPUClass(ctx context.Context, id string, class int) error {
+	_, err := r.client.Collection("clubs").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "max_gpu_class", Value: class},
+	})
+	return err
+}
+
 func (r *clubRepoFS) Create(ctx context.Context, c *entities.Club) error {
 	ref := r.client.Collection("clubs").NewDoc()
 	c.ID = ref.ID
-	_, err := ref.Set(ctx, c)
+	_, err := ref.Set(ctx, newClubDoc(c))
 	return err
 }
 
-func (r *clubRepoFS) Update(ctx context.Context, c *entities.Club) error {
-	_, err := r.client.Collection("clubs").Doc(c.ID).Set(ctx, c)
+// clubDetails are the fields an owner edits in one go; the owner, search
+// counters and archive state are kept by their own operations.
+var clubDetails = []string{
+	"name", "address", "city", "location", "amenities", "rating", "price_per_hour",
+	"category_prices", "hours", "age_restriction", "no_show_policy", "legal", "tax", "timezone",
+}
+
+func (r *clubRepoFS) Update(ctx context.Context, c *entities.Club, fields ...string) error {
+	if len(fields) == 0 {
+		fields = clubDetails
+	}
+	values := map[string]interface{}{
+		"name":            c.Name,
+		"owner_id":        c.OwnerID,
+		"address":         c.Address,
+		"city":            c.City,
+		"location":        c.Location,
+		"amenities":       c.Amenities,
+		"rating":          c.Rating,
+		"price_per_hour":  c.PricePerHour,
+		"category_prices": c.CategoryPrices,
+		"hours":           c.Hours,
+		"age_restriction": c.AgeRestriction,
+		"no_show_policy":  c.NoShowPolicy,
+		"legal":           c.Legal,
+		"tax":             c.Tax,
+		"timezone":        c.Timezone,
+	}
+	updates := make([]firestore.Update, 0, len(fields)+1)
+	for _, f := range fields {
+		v, ok := values[f]
+		if !ok {
+			return fmt.Errorf("club field %q cannot be updated", f)
+		}
+		updates = append(updates, firestore.Update{Path: f, Value: v})
+		if f == "amenities" {
+			updates = append(updates, firestore.Update{Path: "amenity_index", Value: newClubDoc(c).AmenityIndex})
+		}
+	}
+	_, err := r.client.Collection("clubs").Doc(c.ID).Update(ctx, updates)
+	if status.Code(err) == codes.NotFound {
+		return entities.ErrClubNotFound
+	}
 	return err
 }
 
-func (r *clubRepoFS) Delete(ctx context.Context, id string) error {
-	_, err := r.client.Collection("clubs").Doc(id).Delete(ctx)
+func (r *clubRepoFS) Delete(ctx context.Context, c *entities.Club) error {
+	ref := r.client.Collection("clubs").Doc(c.ID)
+	archive := r.client.Collection("deleted_clubs").Doc(c.ID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Set(archive, newClubDoc(c)); err != nil {
+			return err
+		}
+		return tx.Delete(ref)
+	})
+}
+
+func (r *clubRepoFS) Restore(ctx context.Context, id string) (*entities.Club, error) {
+	ref := r.client.Collection("clubs").Doc(id)
+	archive := r.client.Collection("deleted_clubs").Doc(id)
+	var c entities.Club
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(archive)
+		if status.Code(err) == codes.NotFound {
+			return entities.ErrClubNotFound
+		}
+		if err != nil {
+			return err
+		}
+		doc.DataTo(&c)
+		c.ID = doc.Ref.ID
+		c.DeletedAt = time.Time{}
+		if err := tx.Create(ref, newClubDoc(&c)); err != nil {
+			return err
+		}
+		return tx.Delete(archive)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *clubRepoFS) FindDeletedBefore(ctx context.Context, t time.Time) ([]*entities.Club, error) {
+	docs, err := r.client.Collection("deleted_clubs").
+		Where("deleted_at", "<", t).
+		OrderBy("deleted_at", firestore.Desc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return clubsFromDocs(docs), nil
+}
+
+func (r *clubRepoFS) Purge(ctx context.Context, id string) error {
+	_, err := r.client.Collection("deleted_clubs").Doc(id).Delete(ctx)
 	return err
 }
+
+func clubsFromDocs(docs []*firestore.DocumentSnapshot) []*entities.Club {
+	var out []*entities.Club
+	for _, doc := range docs {
+		var c entities.Club
+		doc.DataTo(&c)
+		c.ID = doc.Ref.ID
+		out = append(out, &c)
+	}
+	return out
+}
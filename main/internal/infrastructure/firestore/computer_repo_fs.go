@@ -3,8 +3,13 @@ package firestore
 import (
 	"cloud.google.com/go/firestore"
 	"context"
+	"fmt"
 	"main/internal/domain/entities"
 	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // computerRepoFS implements ComputerRepository using Firestore as backend.
@@ -34,6 +39,9 @@ func (r *computerRepoFS) FindAll(ctx context.Context) ([]*entities.Computer, err
 
 func (r *computerRepoFS) FindByID(ctx context.Context, id string) (*entities.Computer, error) {
 	doc, err := r.client.Collection("computers").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrComputerNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -59,14 +67,237 @@ func (r *computerRepoFS) FindByClub(ctx context.Context, clubID string) ([]*enti
 	return out, nil
 }
 
+func (r *computerRepoFS) Search(ctx context.Context, f entities.ComputerFilter) ([]*entities.Computer, string, error) {
+	col := r.client.Collection("computers")
+	q := col.Query
+	if f.ClubID != "" {
+		q = q.Where("club_id", "==", f.ClubID)
+	}
+	if f.Category != "" {
+		q = q.Where("category", "==", f.Category)
+	}
+	if f.GPU != "" {
+		q = q.Where("specs.gpu_key", "==", f.GPU)
+	}
+	if f.MinHz > 0 {
+		q = q.Where("specs.monitor_hz", ">=", f.MinHz)
+	}
+	if f.MinRAMGB > 0 {
+		q = q.Where("specs.ram_gb", ">=", f.MinRAMGB)
+	}
+	if f.Available != nil {
+		q = q.Where("is_available", "==", *f.Available)
+	}
+	if f.MinGPUClass > 0 {
+		q = q.Where("gpu_class", ">=", f.MinGPUClass)
+	}
+	q = q.OrderBy("club_id", firestore.Asc).
+		OrderBy("pc_number", firestore.Asc).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	docs, next, err := page(ctx, col, q, f.Cursor, f.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	var out []*entities.Computer
+	for _, doc := range docs {
+		var c entities.Computer
+		doc.DataTo(&c)
+		c.ID = doc.Ref.ID
+		out = append(out, &c)
+	}
+	return out, next, nil
+}
+
 func (r *computerRepoFS) Create(ctx context.Context, c *entities.Computer) error {
-	ref := r.client.Collection("computers").NewDoc()
-	c.ID = ref.ID
-	_, err := ref.Set(ctx, c)
-	return err
+	return r.CreateBatch(ctx, []*entities.Computer{c})
+}
+
+// CreateBatch writes the computers and their entries in computer_numbers,
+// which is keyed by club and PC number so a number can be claimed once.
+func (r *computerRepoFS) CreateBatch(ctx context.Context, comps []*entities.Computer) error {
+	col := r.client.Collection("computers")
+	refs := make([]*firestore.DocumentRef, len(comps))
+	for i := range comps {
+		refs[i] = col.NewDoc()
+	}
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, c := range comps {
+			taken, err := r.numberOwner(tx, c.ClubID, c.PCNumber)
+			if err != nil {
+				return err
+			}
+			if taken != "" {
+				return fmt.Errorf("%w: %d", entities.ErrDuplicatePCNumber, c.PCNumber)
+			}
+		}
+		for i, c := range comps {
+			c.ID = refs[i].ID
+			if err := tx.Create(r.numberRef(c.ClubID, c.PCNumber), numberEntry{ComputerID: c.ID}); err != nil {
+				return err
+			}
+			if err := tx.Set(refs[i], c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *computerRepoFS) Delete(ctx context.Context, c *entities.Computer) error {
+	ref := r.client.Collection("computers").Doc(c.ID)
+	archive := r.client.Collection("deleted_computers").Doc(c.ID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		owner, err := r.numberOwner(tx, c.ClubID, c.PCNumber)
+		if err != nil {
+			return err
+		}
+		if owner == c.ID {
+			if err := tx.Delete(r.numberRef(c.ClubID, c.PCNumber)); err != nil {
+				return err
+			}
+		}
+		if err := tx.Set(archive, c); err != nil {
+			return err
+		}
+		return tx.Delete(ref)
+	})
 }
 
+func (r *computerRepoFS) FindArchivedWithClub(ctx context.Context, clubID string) ([]*entities.Computer, error) {
+	docs, err := r.client.Collection("deleted_computers").
+		Where("club_id", "==", clubID).
+		Where("with_club", "==", true).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Computer
+	for _, doc := range docs {
+		var c entities.Computer
+		doc.DataTo(&c)
+		c.ID = doc.Ref.ID
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func (r *computerRepoFS) Restore(ctx context.Context, c *entities.Computer) error {
+	ref := r.client.Collection("computers").Doc(c.ID)
+	archive := r.client.Collection("deleted_computers").Doc(c.ID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		owner, err := r.numberOwner(tx, c.ClubID, c.PCNumber)
+		if err != nil {
+			return err
+		}
+		if owner != "" && owner != c.ID {
+			return fmt.Errorf("%w: %d", entities.ErrDuplicatePCNumber, c.PCNumber)
+		}
+		if err := tx.Set(r.numberRef(c.ClubID, c.PCNumber), numberEntry{ComputerID: c.ID}); err != nil {
+			return err
+		}
+		if err := tx.Create(ref, c); err != nil {
+			return err
+		}
+		return tx.Delete(archive)
+	})
+}
+
+func (r *computerRepoFS) Purge(ctx context.Context, c *entities.Computer) error {
+	archive := r.client.Collection("deleted_computers").Doc(c.ID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		owner, err := r.numberOwner(tx, c.ClubID, c.PCNumber)
+		if err != nil {
+			return err
+		}
+		if owner == c.ID {
+			if err := tx.Delete(r.numberRef(c.ClubID, c.PCNumber)); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(archive)
+	})
+}
+
+// Update saves c and moves its computer_numbers entry when the number
+// changed. Computers created before the index existed are added to it on
+// their first update.
 func (r *computerRepoFS) Update(ctx context.Context, c *entities.Computer) error {
-	_, err := r.client.Collection("computers").Doc(c.ID).Set(ctx, c)
+	ref := r.client.Collection("computers").Doc(c.ID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		oldNumber := 0
+		doc, err := tx.Get(ref)
+		switch {
+		case err == nil:
+			var old entities.Computer
+			doc.DataTo(&old)
+			oldNumber = old.PCNumber
+		case status.Code(err) != codes.NotFound:
+			return err
+		}
+		owner, err := r.numberOwner(tx, c.ClubID, c.PCNumber)
+		if err != nil {
+			return err
+		}
+		if owner != "" && owner != c.ID {
+			return fmt.Errorf("%w: %d", entities.ErrDuplicatePCNumber, c.PCNumber)
+		}
+		if oldNumber != 0 && oldNumber != c.PCNumber {
+			if err := tx.Delete(r.numberRef(c.ClubID, oldNumber)); err != nil {
+				return err
+			}
+		}
+		if err := tx.Set(r.numberRef(c.ClubID, c.PCNumber), numberEntry{ComputerID: c.ID}); err != nil {
+			return err
+		}
+		return tx.Set(ref, c)
+	})
+}
+
+func (r *computerRepoFS) FindAgentsSilentSince(ctx context.Context, t time.Time) ([]*entities.Computer, error) {
+	docs, err := r.client.Collection("computers").
+		Where("agent.online", "==", true).
+		Where("agent.last_seen", "<", t).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Computer
+	for _, doc := range docs {
+		var c entities.Computer
+		doc.DataTo(&c)
+		c.ID = doc.Ref.ID
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func (r *computerRepoFS) SaveAgentStatus(ctx context.Context, id string, st *entities.AgentStatus) error {
+	_, err := r.client.Collection("computers").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "agent", Value: st},
+	})
 	return err
 }
+
+// numberEntry claims a PC number in a club for one computer.
+type numberEntry struct {
+	ComputerID string `firestore:"computer_id"`
+}
+
+func (r *computerRepoFS) numberRef(clubID string, pc int) *firestore.DocumentRef {
+	return r.client.Collection("computer_numbers").Doc(fmt.Sprintf("%s_%d", clubID, pc))
+}
+
+// numberOwner returns the ID of the computer holding a PC number, or "" if
+// the number is free.
+func (r *computerRepoFS) numberOwner(tx *firestore.Transaction, clubID string, pc int) (string, error) {
+	doc, err := tx.Get(r.numberRef(clubID, pc))
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var e numberEntry
+	doc.DataTo(&e)
+	return e.ComputerID, nil
+}
@@ -0,0 +1,46 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// floorPlanRepoFS implements FloorPlanRepository using Firestore as backend.
+// Plans are keyed by club ID.
+type floorPlanRepoFS struct {
+	client *firestore.Client
+}
+
+// NewFloorPlanRepoFS creates a Firestore-based implementation of FloorPlanRepository.
+func NewFloorPlanRepoFS(c *firestore.Client) repository.FloorPlanRepository {
+	return &floorPlanRepoFS{client: c}
+}
+
+func (r *floorPlanRepoFS) FindByClub(ctx context.Context, clubID string) (*entities.FloorPlan, error) {
+	doc, err := r.client.Collection("floor_plans").Doc(clubID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrFloorPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p entities.FloorPlan
+	doc.DataTo(&p)
+	p.ClubID = doc.Ref.ID
+	return &p, nil
+}
+
+func (r *floorPlanRepoFS) Save(ctx context.Context, p *entities.FloorPlan) error {
+	_, err := r.client.Collection("floor_plans").Doc(p.ClubID).Set(ctx, p)
+	return err
+}
+
+func (r *floorPlanRepoFS) Delete(ctx context.Context, clubID string) error {
+	_, err := r.client.Collection("floor_plans").Doc(clubID).Delete(ctx)
+	return err
+}
@@ -0,0 +1,68 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invoiceRepoFS implements InvoiceRepository using Firestore as backend.
+// Invoices are keyed by booking ID, so a booking has at most one.
+type invoiceRepoFS struct {
+	client *firestore.Client
+}
+
+// NewInvoiceRepoFS creates a Firestore-based implementation of InvoiceRepository.
+func NewInvoiceRepoFS(c *firestore.Client) repository.InvoiceRepository {
+	return &invoiceRepoFS{client: c}
+}
+
+func (r *invoiceRepoFS) FindByBooking(ctx context.Context, bookingID string) (*entities.Invoice, error) {
+	doc, err := r.client.Collection("invoices").Doc(bookingID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var inv entities.Invoice
+	doc.DataTo(&inv)
+	inv.ID = doc.Ref.ID
+	return &inv, nil
+}
+
+func (r *invoiceRepoFS) CreateForBooking(ctx context.Context, inv *entities.Invoice) error {
+	ref := r.client.Collection("invoices").Doc(inv.BookingID)
+	counterRef := r.client.Collection("counters").Doc("invoices")
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if doc, err := tx.Get(ref); err == nil {
+			doc.DataTo(inv)
+			inv.ID = doc.Ref.ID
+			return nil
+		} else if status.Code(err) != codes.NotFound {
+			return err
+		}
+		var counter struct {
+			Next int64 `firestore:"next"`
+		}
+		doc, err := tx.Get(counterRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			doc.DataTo(&counter)
+		}
+		counter.Next++
+		inv.ID = ref.ID
+		inv.Number = fmt.Sprintf("INV-%d-%06d", inv.IssuedAt.Year(), counter.Next)
+		if err := tx.Set(counterRef, counter); err != nil {
+			return err
+		}
+		return tx.Create(ref, inv)
+	})
+}
@@ -0,0 +1,363 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loyaltyRepoFS implements LoyaltyRepository using Firestore as backend.
+type loyaltyRepoFS struct {
+	client *firestore.Client
+}
+
+// NewLoyaltyRepoFS creates a Firestore-based implementation of LoyaltyRepository.
+func NewLoyaltyRepoFS(c *firestore.Client) repository.LoyaltyRepository {
+	return &loyaltyRepoFS{client: c}
+}
+
+func (r *loyaltyRepoFS) FindConfig(ctx context.Context, clubID string) (*entities.LoyaltyConfig, error) {
+	doc, err := r.client.Collection("loyalty_configs").Doc(clubID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return &entities.LoyaltyConfig{ClubID: clubID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg entities.LoyaltyConfig
+	doc.DataTo(&cfg)
+	cfg.ClubID = doc.Ref.ID
+	return &cfg, nil
+}
+
+func (r *loyaltyRepoFS) SaveConfig(ctx context.Context, cfg *entities.LoyaltyConfig) error {
+	_, err := r.client.Collection("loyalty_configs").Doc(cfg.ClubID).Set(ctx, cfg)
+	return err
+}
+
+func (r *loyaltyRepoFS) FindAccount(ctx context.Context, userID, clubID string) (*entities.LoyaltyAccount, error) {
+	doc, err := r.accountRef(userID, clubID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		docs, err := r.lotQuery(userID, clubID).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, err
+		}
+		return &entities.LoyaltyAccount{UserID: userID, ClubID: clubID, Balance: lotBalance(docs, time.Now())}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a entities.LoyaltyAccount
+	doc.DataTo(&a)
+	return &a, nil
+}
+
+func (r *loyaltyRepoFS) FindAccounts(ctx context.Context, userID string) ([]*entities.LoyaltyAccount, error) {
+	docs, err := r.client.Collection("loyalty_accounts").
+		Where("user_id", "==", userID).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byClub := map[string]*entities.LoyaltyAccount{}
+	for _, doc := range docs {
+		var a entities.LoyaltyAccount
+		doc.DataTo(&a)
+		// accounts kept before balances were per club
+		if a.ClubID == "" {
+			continue
+		}
+		byClub[a.ClubID] = &a
+	}
+	// clubs whose points predate per-club accounts only have lots
+	lots, err := r.client.Collection("loyalty_transactions").
+		Where("user_id", "==", userID).
+		Where("type", "in", lotTypes).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	legacy := map[string][]*firestore.DocumentSnapshot{}
+	for _, doc := range lots {
+		clubID, _ := doc.Data()["club_id"].(string)
+		if _, ok := byClub[clubID]; !ok && clubID != "" {
+			legacy[clubID] = append(legacy[clubID], doc)
+		}
+	}
+	now := time.Now()
+	for clubID, docs := range legacy {
+		byClub[clubID] = &entities.LoyaltyAccount{UserID: userID, ClubID: clubID, Balance: lotBalance(docs, now)}
+	}
+	out := make([]*entities.LoyaltyAccount, 0, len(byClub))
+	for _, a := range byClub {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ClubID < out[j].ClubID })
+	return out, nil
+}
+
+func (r *loyaltyRepoFS) FindTransactions(ctx context.Context, userID string) ([]*entities.LoyaltyTransaction, error) {
+	docs, err := r.client.Collection("loyalty_transactions").
+		Where("user_id", "==", userID).
+		OrderBy("created_at", firestore.Desc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.LoyaltyTransaction
+	for _, doc := range docs {
+		var t entities.LoyaltyTransaction
+		doc.DataTo(&t)
+		t.ID = doc.Ref.ID
+		out = append(out, &t)
+	}
+	return out, nil
+}
+
+func (r *loyaltyRepoFS) Credit(ctx context.Context, key string, ltx *entities.LoyaltyTransaction) error {
+	txRef := r.ledgerRef(key)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if done, err := r.applied(tx, key, txRef); err != nil || done {
+			return err
+		}
+		acc, err := r.account(tx, ltx.UserID, ltx.ClubID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		ltx.ID = txRef.ID
+		ltx.Remaining = ltx.Points
+		ltx.CreatedAt = now
+		if err := tx.Create(txRef, ltx); err != nil {
+			return err
+		}
+		return tx.Set(r.accountRef(ltx.UserID, ltx.ClubID), &entities.LoyaltyAccount{
+			UserID:    ltx.UserID,
+			ClubID:    ltx.ClubID,
+			Balance:   acc.Balance + ltx.Points,
+			UpdatedAt: now,
+		})
+	})
+}
+
+func (r *loyaltyRepoFS) Debit(ctx context.Context, key string, ltx *entities.LoyaltyTransaction, partial bool) error {
+	txRef := r.ledgerRef(key)
+	want := -ltx.Points
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if done, err := r.applied(tx, key, txRef); err != nil || done {
+			return err
+		}
+		acc, err := r.account(tx, ltx.UserID, ltx.ClubID)
+		if err != nil {
+			return err
+		}
+		docs, err := tx.Documents(r.lotQuery(ltx.UserID, ltx.ClubID)).GetAll()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		type lot struct {
+			ref *firestore.DocumentRef
+			t   entities.LoyaltyTransaction
+		}
+		var lots []lot
+		var available int64
+		for _, doc := range docs {
+			var t entities.LoyaltyTransaction
+			doc.DataTo(&t)
+			if t.Remaining <= 0 || (!t.ExpiresAt.IsZero() && !t.ExpiresAt.After(now)) {
+				continue
+			}
+			lots = append(lots, lot{ref: doc.Ref, t: t})
+			available += t.Remaining
+		}
+		if acc.Balance < available {
+			available = acc.Balance
+		}
+		amount := want
+		if amount > available {
+			if !partial {
+				return entities.ErrInsufficientPoints
+			}
+			amount = available
+		}
+		// lots without expiry are spent last
+		sort.Slice(lots, func(i, j int) bool {
+			a, b := lots[i].t.ExpiresAt, lots[j].t.ExpiresAt
+			if a.IsZero() != b.IsZero() {
+				return b.IsZero()
+			}
+			return a.Before(b)
+		})
+		left := amount
+		for _, l := range lots {
+			if left == 0 {
+				break
+			}
+			take := l.t.Remaining
+			if take > left {
+				take = left
+			}
+			left -= take
+			if err := tx.Update(l.ref, []firestore.Update{
+				{Path: "remaining", Value: l.t.Remaining - take},
+			}); err != nil {
+				return err
+			}
+		}
+
+		ltx.ID = txRef.ID
+		ltx.Points = -amount
+		ltx.CreatedAt = now
+		if err := tx.Create(txRef, ltx); err != nil {
+			return err
+		}
+		return tx.Set(r.accountRef(ltx.UserID, ltx.ClubID), &entities.LoyaltyAccount{
+			UserID:    ltx.UserID,
+			ClubID:    ltx.ClubID,
+			Balance:   acc.Balance - amount,
+			UpdatedAt: now,
+		})
+	})
+}
+
+func (r *loyaltyRepoFS) FindExpiredLots(ctx context.Context, now time.Time) ([]*entities.LoyaltyTransaction, error) {
+	docs, err := r.client.Collection("loyalty_transactions").
+		Where("type", "in", lotTypes).
+		Where("expires_at", "<=", now).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.LoyaltyTransaction
+	for _, doc := range docs {
+		var t entities.LoyaltyTransaction
+		doc.DataTo(&t)
+		if t.Remaining <= 0 || t.ExpiresAt.IsZero() {
+			continue
+		}
+		t.ID = doc.Ref.ID
+		out = append(out, &t)
+	}
+	return out, nil
+}
+
+func (r *loyaltyRepoFS) Expire(ctx context.Context, lotID string) error {
+	lotRef := r.client.Collection("loyalty_transactions").Doc(lotID)
+	txRef := r.ledgerRef("expire_" + lotID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(lotRef)
+		if err != nil {
+			return err
+		}
+		var lot entities.LoyaltyTransaction
+		doc.DataTo(&lot)
+		if lot.Remaining <= 0 {
+			return nil
+		}
+		acc, err := r.account(tx, lot.UserID, lot.ClubID)
+		if err != nil {
+			return err
+		}
+		amount := lot.Remaining
+		if amount > acc.Balance {
+			amount = acc.Balance
+		}
+		now := time.Now()
+		if err := tx.Update(lotRef, []firestore.Update{{Path: "remaining", Value: 0}}); err != nil {
+			return err
+		}
+		if err := tx.Set(txRef, &entities.LoyaltyTransaction{
+			ID:        txRef.ID,
+			UserID:    lot.UserID,
+			ClubID:    lot.ClubID,
+			Type:      entities.PointsExpire,
+			Points:    -amount,
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+		return tx.Set(r.accountRef(lot.UserID, lot.ClubID), &entities.LoyaltyAccount{
+			UserID:    lot.UserID,
+			ClubID:    lot.ClubID,
+			Balance:   acc.Balance - amount,
+			UpdatedAt: now,
+		})
+	})
+}
+
+func (r *loyaltyRepoFS) ledgerRef(key string) *firestore.DocumentRef {
+	if key == "" {
+		return r.client.Collection("loyalty_transactions").NewDoc()
+	}
+	return r.client.Collection("loyalty_transactions").Doc(key)
+}
+
+// applied reports whether a keyed ledger entry already exists.
+func (r *loyaltyRepoFS) applied(tx *firestore.Transaction, key string, ref *firestore.DocumentRef) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+	_, err := tx.Get(ref)
+	if err == nil {
+		return true, nil
+	}
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+// lotTypes are the ledger entries that hold spendable points.
+var lotTypes = []string{entities.PointsEarn, entities.PointsRestore}
+
+func (r *loyaltyRepoFS) accountRef(userID, clubID string) *firestore.DocumentRef {
+	return r.client.Collection("loyalty_accounts").Doc(userID + "_" + clubID)
+}
+
+func (r *loyaltyRepoFS) lotQuery(userID, clubID string) firestore.Query {
+	return r.client.Collection("loyalty_transactions").
+		Where("user_id", "==", userID).
+		Where("club_id", "==", clubID).
+		Where("type", "in", lotTypes)
+}
+
+// account reads the user's account at clubID. Points earned before
+// balances were kept per club are counted from the club's lots.
+func (r *loyaltyRepoFS) account(tx *firestore.Transaction, userID, clubID string) (*entities.LoyaltyAccount, error) {
+	var acc entities.LoyaltyAccount
+	doc, err := tx.Get(r.accountRef(userID, clubID))
+	if status.Code(err) == codes.NotFound {
+		docs, err := tx.Documents(r.lotQuery(userID, clubID)).GetAll()
+		if err != nil {
+			return nil, err
+		}
+		acc.Balance = lotBalance(docs, time.Now())
+		return &acc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.DataTo(&acc)
+	return &acc, nil
+}
+
+// lotBalance sums the unspent, unexpired points of lots.
+func lotBalance(docs []*firestore.DocumentSnapshot, now time.Time) int64 {
+	var sum int64
+	for _, doc := range docs {
+		var t entities.LoyaltyTransaction
+		doc.DataTo(&t)
+		if t.Remaining > 0 && (t.ExpiresAt.IsZero() || t.ExpiresAt.After(now)) {
+			sum += t.Remaining
+		}
+	}
+	return sum
+}
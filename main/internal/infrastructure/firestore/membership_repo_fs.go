@@ -0,0 +1,170 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"errors"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// membershipRepoFS implements MembershipRepository using Firestore as backend.
+type membershipRepoFS struct {
+	client *firestore.Client
+}
+
+// NewMembershipRepoFS creates a Firestore-based implementation of MembershipRepository.
+func NewMembershipRepoFS(c *firestore.Client) repository.MembershipRepository {
+	return &membershipRepoFS{client: c}
+}
+
+func (r *membershipRepoFS) FindPlansByClub(ctx context.Context, clubID string) ([]*entities.MembershipPlan, error) {
+	docs, err := r.client.Collection("membership_plans").Where("club_id", "==", clubID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.MembershipPlan
+	for _, doc := range docs {
+		var p entities.MembershipPlan
+		doc.DataTo(&p)
+		p.ID = doc.Ref.ID
+		out = append(out, &p)
+	}
+	return out, nil
+}
+
+func (r *membershipRepoFS) FindPlanByID(ctx context.Context, id string) (*entities.MembershipPlan, error) {
+	doc, err := r.client.Collection("membership_plans").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p entities.MembershipPlan
+	doc.DataTo(&p)
+	p.ID = doc.Ref.ID
+	return &p, nil
+}
+
+func (r *membershipRepoFS) CreatePlan(ctx context.Context, p *entities.MembershipPlan) error {
+	ref := r.client.Collection("membership_plans").NewDoc()
+	p.ID = ref.ID
+	_, err := ref.Set(ctx, p)
+	return err
+}
+
+func (r *membershipRepoFS) UpdatePlan(ctx context.Context, p *entities.MembershipPlan) error {
+	_, err := r.client.Collection("membership_plans").Doc(p.ID).Set(ctx, p)
+	return err
+}
+
+func (r *membershipRepoFS) FindByID(ctx context.Context, id string) (*entities.Membership, error) {
+	doc, err := r.client.Collection("memberships").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var m entities.Membership
+	doc.DataTo(&m)
+	m.ID = doc.Ref.ID
+	return &m, nil
+}
+
+func (r *membershipRepoFS) FindByUser(ctx context.Context, userID string) ([]*entities.Membership, error) {
+	docs, err := r.client.Collection("memberships").Where("user_id", "==", userID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.Membership
+	for _, doc := range docs {
+		var m entities.Membership
+		doc.DataTo(&m)
+		m.ID = doc.Ref.ID
+		out = append(out, &m)
+	}
+	return out, nil
+}
+
+func (r *membershipRepoFS) FindByStripeSubscription(ctx context.Context, subscriptionID string) (*entities.Membership, error) {
+	docs, err := r.client.Collection("memberships").
+		Where("stripe_subscription_id", "==", subscriptionID).
+		Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("membership not found")
+	}
+	var m entities.Membership
+	docs[0].DataTo(&m)
+	m.ID = docs[0].Ref.ID
+	return &m, nil
+}
+
+func (r *membershipRepoFS) Create(ctx context.Context, m *entities.Membership) error {
+	ref := r.client.Collection("memberships").NewDoc()
+	m.ID = ref.ID
+	_, err := ref.Set(ctx, m)
+	return err
+}
+
+func (r *membershipRepoFS) Update(ctx context.Context, m *entities.Membership) error {
+	_, err := r.client.Collection("memberships").Doc(m.ID).Set(ctx, m)
+	return err
+}
+
+func (r *membershipRepoFS) Delete(ctx context.Context, id string) error {
+	_, err := r.client.Collection("memberships").Doc(id).Delete(ctx)
+	return err
+}
+
+func (r *membershipRepoFS) ConsumeHours(ctx context.Context, id string, hours, limit float64) error {
+	ref := r.client.Collection("memberships").Doc(id)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var m entities.Membership
+		doc.DataTo(&m)
+		if limit > 0 && m.HoursUsed+hours > limit {
+			return entities.ErrMembershipHoursExhausted
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "hours_used", Value: firestore.Increment(hours)},
+		})
+	})
+}
+
+func (r *membershipRepoFS) ReturnHours(ctx context.Context, key, id string, hours float64) error {
+	ref := r.client.Collection("memberships").Doc(id)
+	if key == "" {
+		_, err := ref.Update(ctx, []firestore.Update{
+			{Path: "hours_used", Value: firestore.Increment(-hours)},
+		})
+		return err
+	}
+	// the marker document makes the return happen once per key
+	marker := r.client.Collection("membership_hour_returns").Doc(key)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(marker); err == nil {
+			return nil
+		} else if status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err := tx.Create(marker, map[string]interface{}{
+			"membership_id": id,
+			"hours":         hours,
+			"created_at":    time.Now(),
+		}); err != nil {
+			return err
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "hours_used", Value: firestore.Increment(-hours)},
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// page runs q starting after the document whose ID is cursor and returns at
+// most limit documents and the cursor of the next page. The cursor is a
+// document ID, so q must order by firestore.DocumentID last.
+func page(ctx context.Context, col *firestore.CollectionRef, q firestore.Query, cursor string, limit int) ([]*firestore.DocumentSnapshot, string, error) {
+	return pageWhere(ctx, col, q, cursor, limit, nil)
+}
+
+// pageWhere is page for constraints a query cannot express: it reads on
+// in batches until limit documents pass keep, so pages stay full.
+func pageWhere(ctx context.Context, col *firestore.CollectionRef, q firestore.Query, cursor string, limit int, keep func(*firestore.DocumentSnapshot) bool) ([]*firestore.DocumentSnapshot, string, error) {
+	if cursor != "" {
+		after, err := col.Doc(cursor).Get(ctx)
+		if status.Code(err) == codes.NotFound {
+			return nil, "", entities.ErrInvalidCursor
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		q = q.StartAfter(after)
+	}
+	// one extra document tells whether another page exists
+	batch := limit + 1
+	var out []*firestore.DocumentSnapshot
+	for {
+		docs, err := q.Limit(batch).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, "", err
+		}
+		for _, doc := range docs {
+			if keep != nil && !keep(doc) {
+				continue
+			}
+			if len(out) == limit {
+				return out, out[limit-1].Ref.ID, nil
+			}
+			out = append(out, doc)
+		}
+		if len(docs) < batch {
+			return out, "", nil
+		}
+		q = q.StartAfter(docs[len(docs)-1])
+	}
+}
@@ -0,0 +1,98 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"errors"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// paymentLedgerRepoFS implements PaymentLedgerRepository using Firestore as backend.
+type paymentLedgerRepoFS struct {
+	client *firestore.Client
+}
+
+// NewPaymentLedgerRepoFS creates a Firestore-based implementation of PaymentLedgerRepository.
+func NewPaymentLedgerRepoFS(c *firestore.Client) repository.PaymentLedgerRepository {
+	return &paymentLedgerRepoFS{client: c}
+}
+
+func (r *paymentLedgerRepoFS) Record(ctx context.Context, key string, e *entities.PaymentLedgerEntry) error {
+	ref := r.client.Collection("payment_ledger").Doc(key)
+	e.ID = ref.ID
+	_, err := ref.Create(ctx, e)
+	if err != nil {
+		e.ID = ""
+	}
+	if status.Code(err) == codes.AlreadyExists {
+		return nil
+	}
+	return err
+}
+
+func (r *paymentLedgerRepoFS) FindByProviderRef(ctx context.Context, ref string) (*entities.PaymentLedgerEntry, error) {
+	docs, err := r.client.Collection("payment_ledger").Where("provider_ref", "==", ref).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("ledger entry not found")
+	}
+	var e entities.PaymentLedgerEntry
+	docs[0].DataTo(&e)
+	e.ID = docs[0].Ref.ID
+	return &e, nil
+}
+
+func (r *paymentLedgerRepoFS) FindByBooking(ctx context.Context, bookingID string) ([]*entities.PaymentLedgerEntry, error) {
+	return r.query(ctx, r.client.Collection("payment_ledger").Where("booking_id", "==", bookingID))
+}
+
+func (r *paymentLedgerRepoFS) FindByPeriod(ctx context.Context, from, to time.Time) ([]*entities.PaymentLedgerEntry, error) {
+	return r.query(ctx, r.client.Collection("payment_ledger").
+		Where("created_at", ">=", from).
+		Where("created_at", "<", to))
+}
+
+func (r *paymentLedgerRepoFS) FindByClub(ctx context.Context, clubID string, from, to time.Time) ([]*entities.PaymentLedgerEntry, error) {
+	return r.query(ctx, r.client.Collection("payment_ledger").
+		Where("club_id", "==", clubID).
+		Where("created_at", ">=", from).
+		Where("created_at", "<", to))
+}
+
+func (r *paymentLedgerRepoFS) query(ctx context.Context, q firestore.Query) ([]*entities.PaymentLedgerEntry, error) {
+	docs, err := q.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.PaymentLedgerEntry
+	for _, doc := range docs {
+		var e entities.PaymentLedgerEntry
+		doc.DataTo(&e)
+		e.ID = doc.Ref.ID
+		out = append(out, &e)
+	}
+	return out, nil
+}
+
+func (r *paymentLedgerRepoFS) SaveReconciliation(ctx context.Context, rep *entities.ReconciliationReport) error {
+	_, err := r.client.Collection("reconciliation_reports").Doc(rep.ID).Set(ctx, rep)
+	return err
+}
+
+func (r *paymentLedgerRepoFS) FindReconciliation(ctx context.Context, id string) (*entities.ReconciliationReport, error) {
+	doc, err := r.client.Collection("reconciliation_reports").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rep entities.ReconciliationReport
+	doc.DataTo(&rep)
+	rep.ID = doc.Ref.ID
+	return &rep, nil
+}
@@ -0,0 +1,171 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// promoCodeRepoFS implements PromoCodeRepository using Firestore as backend.
+type promoCodeRepoFS struct {
+	client *firestore.Client
+}
+
+// NewPromoCodeRepoFS creates a Firestore-based implementation of PromoCodeRepository.
+func NewPromoCodeRepoFS(c *firestore.Client) repository.PromoCodeRepository {
+	return &promoCodeRepoFS{client: c}
+}
+
+func (r *promoCodeRepoFS) FindAll(ctx context.Context) ([]*entities.PromoCode, error) {
+	docs, err := r.client.Collection("promo_codes").Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.PromoCode
+	for _, doc := range docs {
+		var p entities.PromoCode
+		doc.DataTo(&p)
+		p.ID = doc.Ref.ID
+		out = append(out, &p)
+	}
+	return out, nil
+}
+
+func (r *promoCodeRepoFS) FindByID(ctx context.Context, id string) (*entities.PromoCode, error) {
+	doc, err := r.client.Collection("promo_codes").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var p entities.PromoCode
+	doc.DataTo(&p)
+	p.ID = doc.Ref.ID
+	return &p, nil
+}
+
+func (r *promoCodeRepoFS) FindByCode(ctx context.Context, code string) (*entities.PromoCode, error) {
+	docs, err := r.client.Collection("promo_codes").Where("code", "==", code).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, entities.ErrPromoCodeNotFound
+	}
+	var p entities.PromoCode
+	docs[0].DataTo(&p)
+	p.ID = docs[0].Ref.ID
+	return &p, nil
+}
+
+func (r *promoCodeRepoFS) CountUserRedemptions(ctx context.Context, promoID, userID string) (int, error) {
+	docs, err := r.client.Collection("promo_redemptions").
+		Where("promo_code_id", "==", promoID).
+		Where("user_id", "==", userID).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+func (r *promoCodeRepoFS) Create(ctx context.Context, p *entities.PromoCode) error {
+	ref := r.client.Collection("promo_codes").NewDoc()
+	p.ID = ref.ID
+	_, err := ref.Set(ctx, p)
+	return err
+}
+
+func (r *promoCodeRepoFS) Update(ctx context.Context, p *entities.PromoCode) error {
+	_, err := r.client.Collection("promo_codes").Doc(p.ID).Set(ctx, p)
+	return err
+}
+
+func (r *promoCodeRepoFS) Delete(ctx context.Context, id string) error {
+	_, err := r.client.Collection("promo_codes").Doc(id).Delete(ctx)
+	return err
+}
+
+// Redeem runs inside a transaction so that concurrent checkouts observe each
+// other's increments and redemptions; Firestore retries the transaction on
+// contention. A first-booking code is refused while the user has a held or
+// paid booking or another first-booking redemption, which covers checkouts
+// whose bookings are not saved yet.
+func (r *promoCodeRepoFS) Redeem(ctx context.Context, red *entities.PromoRedemption) error {
+	promoRef := r.client.Collection("promo_codes").Doc(red.PromoCodeID)
+	redRef := r.client.Collection("promo_redemptions").NewDoc()
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(promoRef)
+		if err != nil {
+			return err
+		}
+		var p entities.PromoCode
+		doc.DataTo(&p)
+		if p.MaxUses > 0 && p.UsedCount >= p.MaxUses {
+			return entities.ErrPromoCodeExhausted
+		}
+		if p.MaxUsesPerUser > 0 {
+			q := r.client.Collection("promo_redemptions").
+				Where("promo_code_id", "==", red.PromoCodeID).
+				Where("user_id", "==", red.UserID)
+			used, err := tx.Documents(q).GetAll()
+			if err != nil {
+				return err
+			}
+			if len(used) >= p.MaxUsesPerUser {
+				return entities.ErrPromoCodeUserLimit
+			}
+		}
+		if p.FirstBookingOnly {
+			booked, err := tx.Documents(userBookings(r.client, red.UserID).Limit(1)).GetAll()
+			if err != nil {
+				return err
+			}
+			q := r.client.Collection("promo_redemptions").
+				Where("user_id", "==", red.UserID).
+				Where("first_booking", "==", true).
+				Limit(1)
+			redeemed, err := tx.Documents(q).GetAll()
+			if err != nil {
+				return err
+			}
+			if len(booked) > 0 || len(redeemed) > 0 {
+				return entities.ErrPromoCodeFirstBooking
+			}
+			red.FirstBooking = true
+		}
+		red.ID = redRef.ID
+		red.CreatedAt = time.Now()
+		if err := tx.Update(promoRef, []firestore.Update{
+			{Path: "used_count", Value: firestore.Increment(1)},
+		}); err != nil {
+			return err
+		}
+		return tx.Create(redRef, red)
+	})
+}
+
+func (r *promoCodeRepoFS) Release(ctx context.Context, redemptionID string) error {
+	redRef := r.client.Collection("promo_redemptions").Doc(redemptionID)
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(redRef)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var red entities.PromoRedemption
+		doc.DataTo(&red)
+		promoRef := r.client.Collection("promo_codes").Doc(red.PromoCodeID)
+		if err := tx.Update(promoRef, []firestore.Update{
+			{Path: "used_count", Value: firestore.Increment(-1)},
+		}); err != nil {
+			return err
+		}
+		return tx.Delete(redRef)
+	})
+}
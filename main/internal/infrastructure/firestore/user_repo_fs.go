@@ -0,0 +1,120 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"fmt"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userRepoFS implements UserRepository using Firestore as backend. Profiles
+// are keyed by Firebase UID.
+type userRepoFS struct {
+	client *firestore.Client
+}
+
+// NewUserRepoFS creates a Firestore-based implementation of UserRepository.
+func NewUserRepoFS(c *firestore.Client) repository.UserRepository {
+	return &userRepoFS{client: c}
+}
+
+func (r *userRepoFS) FindByID(ctx context.Context, id string) (*entities.User, error) {
+	doc, err := r.client.Collection("users").Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, entities.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var u entities.User
+	doc.DataTo(&u)
+	u.ID = doc.Ref.ID
+	return &u, nil
+}
+
+func (r *userRepoFS) FindByIDs(ctx context.Context, ids []string) (map[string]*entities.User, error) {
+	out := make(map[string]*entities.User, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.Collection("users").Doc(id)
+	}
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var u entities.User
+		doc.DataTo(&u)
+		u.ID = doc.Ref.ID
+		out[u.ID] = &u
+	}
+	return out, nil
+}
+
+func (r *userRepoFS) Create(ctx context.Context, u *entities.User) (*entities.User, error) {
+	_, err := r.client.Collection("users").Doc(u.ID).Create(ctx, u)
+	if status.Code(err) == codes.AlreadyExists {
+		return r.FindByID(ctx, u.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *userRepoFS) Update(ctx context.Context, u *entities.User, fields ...string) error {
+	values := map[string]interface{}{
+		"display_name":     u.DisplayName,
+		"phone":            u.Phone,
+		"nickname":         u.Nickname,
+		"birth_date":       u.BirthDate,
+		"age_verification": u.AgeVerification,
+		"preferred_clubs":  u.PreferredClubs,
+		"language":         u.Language,
+		"updated_at":       u.UpdatedAt,
+	}
+	updates := make([]firestore.Update, 0, len(fields))
+	for _, f := range fields {
+		v, ok := values[f]
+		if !ok {
+			return fmt.Errorf("user field %q cannot be updated", f)
+		}
+		updates = append(updates, firestore.Update{Path: f, Value: v})
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	_, err := r.client.Collection("users").Doc(u.ID).Update(ctx, updates)
+	if status.Code(err) == codes.NotFound {
+		return entities.ErrUserNotFound
+	}
+	return err
+}
+
+func (r *userRepoFS) FindByVerificationStatus(ctx context.Context, st string) ([]*entities.User, error) {
+	docs, err := r.client.Collection("users").
+		Where("age_verification.status", "==", st).
+		OrderBy("age_verification.requested_at", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*entities.User, 0, len(docs))
+	for _, doc := range docs {
+		var u entities.User
+		doc.DataTo(&u)
+		u.ID = doc.Ref.ID
+		list = append(list, &u)
+	}
+	return list, nil
+}
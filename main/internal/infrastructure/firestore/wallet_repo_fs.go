@@ -0,0 +1,98 @@
+package firestore
+
+import (
+	"cloud.google.com/go/firestore"
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// walletRepoFS implements WalletRepository using Firestore as backend.
+type walletRepoFS struct {
+	client *firestore.Client
+}
+
+// NewWalletRepoFS creates a Firestore-based implementation of WalletRepository.
+func NewWalletRepoFS(c *firestore.Client) repository.WalletRepository {
+	return &walletRepoFS{client: c}
+}
+
+func (r *walletRepoFS) FindByUser(ctx context.Context, userID string) (*entities.Wallet, error) {
+	doc, err := r.client.Collection("wallets").Doc(userID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return &entities.Wallet{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var w entities.Wallet
+	doc.DataTo(&w)
+	w.UserID = doc.Ref.ID
+	return &w, nil
+}
+
+func (r *walletRepoFS) FindTransactions(ctx context.Context, userID string) ([]*entities.WalletTransaction, error) {
+	docs, err := r.client.Collection("wallet_transactions").
+		Where("user_id", "==", userID).
+		OrderBy("created_at", firestore.Desc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []*entities.WalletTransaction
+	for _, doc := range docs {
+		var t entities.WalletTransaction
+		doc.DataTo(&t)
+		t.ID = doc.Ref.ID
+		out = append(out, &t)
+	}
+	return out, nil
+}
+
+func (r *walletRepoFS) Apply(ctx context.Context, key string, wtx *entities.WalletTransaction) error {
+	walletRef := r.client.Collection("wallets").Doc(wtx.UserID)
+	var txRef *firestore.DocumentRef
+	if key != "" {
+		txRef = r.client.Collection("wallet_transactions").Doc(key)
+	} else {
+		txRef = r.client.Collection("wallet_transactions").NewDoc()
+	}
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if key != "" {
+			if _, err := tx.Get(txRef); err == nil {
+				return nil
+			} else if status.Code(err) != codes.NotFound {
+				return err
+			}
+		}
+		var w entities.Wallet
+		doc, err := tx.Get(walletRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			doc.DataTo(&w)
+		}
+
+		balance := w.Balance + wtx.Amount
+		if balance < 0 {
+			return entities.ErrInsufficientFunds
+		}
+		now := time.Now()
+		wtx.ID = txRef.ID
+		wtx.BalanceAfter = balance
+		wtx.CreatedAt = now
+		if err := tx.Create(txRef, wtx); err != nil {
+			return err
+		}
+		return tx.Set(walletRef, &entities.Wallet{
+			UserID:    wtx.UserID,
+			Balance:   balance,
+			UpdatedAt: now,
+		})
+	})
+}
@@ -0,0 +1,100 @@
+package pdf
+
+import (
+	"fmt"
+	"main/internal/domain/entities"
+	"strings"
+)
+
+// RenderInvoice lays out an invoice as a single-page A4 receipt.
+func RenderInvoice(inv *entities.Invoice) []byte {
+	d := New()
+	d.AddPage()
+
+	const left, right = 50.0, PageWidth - 50
+	y := 70.0
+	d.Text(left, y, HelveticaBold, 18, "Receipt "+inv.Number)
+	y += 20
+	d.Text(left, y, Helvetica, 10, "Issued "+inv.IssuedAt.In(entities.LoadTimezone(inv.Timezone)).Format("2006-01-02 15:04 MST"))
+
+	y += 30
+	d.Text(left, y, HelveticaBold, 11, "Seller")
+	seller := []string{inv.Seller.Name, inv.Seller.Address}
+	if inv.Seller.BIN != "" {
+		seller = append(seller, "BIN "+inv.Seller.BIN)
+	}
+	if inv.TaxID != "" && inv.TaxID != inv.Seller.BIN {
+		seller = append(seller, "Tax ID "+inv.TaxID)
+	}
+	if inv.ClubName != "" && inv.ClubName != inv.Seller.Name {
+		seller = append(seller, "Club: "+inv.ClubName)
+	}
+	for _, s := range seller {
+		if s == "" {
+			continue
+		}
+		y += 14
+		d.Text(left, y, Helvetica, 10, s)
+	}
+
+	y += 30
+	d.Text(left, y, HelveticaBold, 10, "Description")
+	amountCol := func(s string, size float64) float64 { return right - CourierWidth(s, size) }
+	d.Text(330, y, HelveticaBold, 10, "Qty")
+	d.Text(390, y, HelveticaBold, 10, "Unit")
+	d.Text(right-40, y, HelveticaBold, 10, "Amount")
+	y += 6
+	d.Line(left, y, right, y)
+	for _, it := range inv.LineItems {
+		// tax is shown with the totals below
+		if it.Kind == entities.LineItemTax {
+			continue
+		}
+		y += 16
+		d.Text(left, y, Helvetica, 10, it.Description)
+		d.Text(330, y, Courier, 10, trimFloat(it.Quantity))
+		d.Text(390, y, Courier, 10, money(it.UnitPrice))
+		a := money(it.Amount)
+		d.Text(amountCol(a, 10), y, Courier, 10, a)
+	}
+	y += 8
+	d.Line(left, y, right, y)
+
+	totals := [][2]string{{"Subtotal", money(inv.Subtotal)}}
+	if inv.Discount > 0 {
+		totals = append(totals, [2]string{"Discount", money(-inv.Discount)})
+	}
+	if inv.TaxRate > 0 && !inv.TaxInclusive {
+		totals = append(totals, [2]string{fmt.Sprintf("VAT %s%%", trimFloat(inv.TaxRate)), money(inv.TaxAmount)})
+	}
+	totalRow := len(totals)
+	totals = append(totals, [2]string{"Total " + strings.ToUpper(inv.Currency), money(inv.Total)})
+	if inv.TaxRate > 0 && inv.TaxInclusive {
+		totals = append(totals, [2]string{fmt.Sprintf("incl. VAT %s%%", trimFloat(inv.TaxRate)), money(inv.TaxAmount)})
+	}
+	for i, t := range totals {
+		y += 16
+		font := Helvetica
+		if i == totalRow {
+			font = HelveticaBold
+		}
+		d.Text(330, y, font, 10, t[0])
+		d.Text(amountCol(t[1], 10), y, Courier, 10, t[1])
+	}
+
+	y += 40
+	d.Text(left, y, Helvetica, 10, "Payment method: "+inv.PaymentMethod)
+	y += 14
+	d.Text(left, y, Helvetica, 10, "Payment reference: "+inv.PaymentReference)
+	y += 14
+	d.Text(left, y, Helvetica, 10, "Booking: "+inv.BookingID)
+	return d.Bytes()
+}
+
+func money(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+func trimFloat(v float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
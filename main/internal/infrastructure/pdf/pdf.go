@@ -0,0 +1,168 @@
+// Package pdf is a minimal PDF 1.4 writer for simple text documents. It uses
+// only the standard Type 1 fonts, so nothing is embedded and no cgo or system
+// fonts are needed.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Fonts available to Text.
+const (
+	Helvetica     = "F1"
+	HelveticaBold = "F2"
+	Courier       = "F3"
+)
+
+var baseFonts = []struct{ key, name string }{
+	{Helvetica, "Helvetica"},
+	{HelveticaBold, "Helvetica-Bold"},
+	{Courier, "Courier"},
+}
+
+// Document accumulates pages of drawing operations.
+type Document struct {
+	pages []*bytes.Buffer
+}
+
+// New returns an empty document.
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage starts a new A4 page; subsequent drawing goes to it.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// Text draws s with its baseline at (x, y), measured from the top-left corner.
+func (d *Document) Text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		font, size, x, PageHeight-y, escape(s))
+}
+
+// CourierWidth returns the width of s set in Courier at size.
+func CourierWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * 0.6 * size
+}
+
+// Line draws a thin line between two points measured from the top-left corner.
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n",
+		x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Bytes serialises the document.
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	// object numbers: 1 catalog, 2 pages, 3.. fonts, then page/content pairs
+	fontStart := 3
+	pageStart := fontStart + len(baseFonts)
+	var kids, fontRefs []string
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageStart+2*i))
+	}
+	for i, f := range baseFonts {
+		fontRefs = append(fontRefs, fmt.Sprintf("/%s %d 0 R", f.key, fontStart+i))
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, f := range baseFonts {
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.name))
+	}
+	for i, p := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, strings.Join(fontRefs, " "), pageStart+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.Len(), p.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape converts s to a WinAnsi PDF string literal body. Cyrillic is
+// transliterated since the standard fonts have no Cyrillic glyphs; other
+// characters outside Latin-1 become '?'.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if t, ok := translit[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20:
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+var translit = func() map[rune]string {
+	pairs := []string{
+		"а", "a", "б", "b", "в", "v", "г", "g", "д", "d", "е", "e", "ё", "e",
+		"ж", "zh", "з", "z", "и", "i", "й", "i", "к", "k", "л", "l", "м", "m",
+		"н", "n", "о", "o", "п", "p", "р", "r", "с", "s", "т", "t", "у", "u",
+		"ф", "f", "х", "kh", "ц", "ts", "ч", "ch", "ш", "sh", "щ", "shch",
+		"ъ", "", "ы", "y", "ь", "", "э", "e", "ю", "iu", "я", "ia",
+		// Kazakh letters
+		"ә", "a", "ғ", "g", "қ", "q", "ң", "n", "ө", "o", "ұ", "u", "ү", "u",
+		"һ", "h", "і", "i",
+	}
+	m := make(map[rune]string, len(pairs))
+	for i := 0; i < len(pairs); i += 2 {
+		lower := []rune(pairs[i])[0]
+		m[lower] = pairs[i+1]
+		upper := []rune(strings.ToUpper(pairs[i]))[0]
+		m[upper] = capitalize(pairs[i+1])
+	}
+	return m
+}()
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,60 @@
+package stripeclient
+
+import (
+	"context"
+	"main/internal/domain/entities"
+	"main/internal/domain/repository"
+	"time"
+
+	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/balancetransaction"
+)
+
+// gateway implements PaymentGateway on top of Stripe balance transactions.
+type gateway struct{}
+
+// NewGateway creates a Stripe-backed PaymentGateway. Init must be called first.
+func NewGateway() repository.PaymentGateway {
+	return &gateway{}
+}
+
+func (g *gateway) BalanceTransaction(ctx context.Context, id string) (*entities.ProviderTransaction, error) {
+	params := &stripe.BalanceTransactionParams{}
+	params.Context = ctx
+	bt, err := balancetransaction.Get(id, params)
+	if err != nil {
+		return nil, err
+	}
+	return toProviderTransaction(bt), nil
+}
+
+func (g *gateway) BalanceTransactions(ctx context.Context, from, to time.Time) ([]*entities.ProviderTransaction, error) {
+	params := &stripe.BalanceTransactionListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: from.Unix(),
+			LesserThan:         to.Unix(),
+		},
+	}
+	params.Context = ctx
+	var out []*entities.ProviderTransaction
+	it := balancetransaction.List(params)
+	for it.Next() {
+		out = append(out, toProviderTransaction(it.BalanceTransaction()))
+	}
+	return out, it.Err()
+}
+
+func toProviderTransaction(bt *stripe.BalanceTransaction) *entities.ProviderTransaction {
+	t := &entities.ProviderTransaction{
+		ID:       bt.ID,
+		Type:     string(bt.Type),
+		Amount:   bt.Amount,
+		Fee:      bt.Fee,
+		Currency: string(bt.Currency),
+		Created:  time.Unix(bt.Created, 0),
+	}
+	if bt.Source != nil {
+		t.SourceID = bt.Source.ID
+	}
+	return t
+}
@@ -2,7 +2,12 @@ package stripeclient
 
 import (
 	"github.com/stripe/stripe-go/v72"
+	"github.com/stripe/stripe-go/v72/checkout/session"
+	"github.com/stripe/stripe-go/v72/customer"
 	"github.com/stripe/stripe-go/v72/paymentintent"
+	"github.com/stripe/stripe-go/v72/price"
+	"github.com/stripe/stripe-go/v72/refund"
+	"github.com/stripe/stripe-go/v72/sub"
 )
 
 // Init sets Stripe API key
@@ -21,3 +26,154 @@ func CreatePaymentIntent(amount int64, currency string, metadata map[string]stri
 	}
 	return paymentintent.New(params)
 }
+
+// GetPaymentIntent fetches a PaymentIntent
+func GetPaymentIntent(id string) (*stripe.PaymentIntent, error) {
+	return paymentintent.Get(id, nil)
+}
+
+// CancelPaymentIntent cancels a PaymentIntent that has not been paid
+func CancelPaymentIntent(id string) (*stripe.PaymentIntent, error) {
+	return paymentintent.Cancel(id, nil)
+}
+
+// States of a PaymentIntent or Checkout Session as far as money is concerned.
+const (
+	// PaymentOpen means the customer can still pay.
+	PaymentOpen = "open"
+	// PaymentClosed means it was cancelled or expired and takes no money.
+	PaymentClosed = "closed"
+	// PaymentTaken means money was taken or is being processed.
+	PaymentTaken = "taken"
+)
+
+// IntentState reports where pi stands as far as money is concerned.
+func IntentState(pi *stripe.PaymentIntent) string {
+	switch pi.Status {
+	case stripe.PaymentIntentStatusRequiresPaymentMethod,
+		stripe.PaymentIntentStatusRequiresConfirmation,
+		stripe.PaymentIntentStatusRequiresAction:
+		return PaymentOpen
+	case stripe.PaymentIntentStatusCanceled:
+		return PaymentClosed
+	}
+	return PaymentTaken
+}
+
+// CreateCustomer creates a new Customer
+func CreateCustomer(metadata map[string]string) (*stripe.Customer, error) {
+	params := &stripe.CustomerParams{
+		Params: stripe.Params{
+			Metadata: metadata,
+		},
+	}
+	return customer.New(params)
+}
+
+// CreateMonthlyPrice creates a recurring Price billed every intervalCount months
+func CreateMonthlyPrice(name string, amount int64, currency string, intervalCount int64) (*stripe.Price, error) {
+	params := &stripe.PriceParams{
+		Currency:   stripe.String(currency),
+		UnitAmount: stripe.Int64(amount),
+		ProductData: &stripe.PriceProductDataParams{
+			Name: stripe.String(name),
+		},
+		Recurring: &stripe.PriceRecurringParams{
+			Interval:      stripe.String("month"),
+			IntervalCount: stripe.Int64(intervalCount),
+		},
+	}
+	return price.New(params)
+}
+
+// CreateSubscription creates an incomplete Subscription whose first invoice
+// is paid through the expanded latest_invoice.payment_intent
+func CreateSubscription(customerID, priceID string, metadata map[string]string) (*stripe.Subscription, error) {
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(customerID),
+		Items: []*stripe.SubscriptionItemsParams{
+			{Price: stripe.String(priceID)},
+		},
+		PaymentBehavior: stripe.String("default_incomplete"),
+	}
+	params.Metadata = metadata
+	params.AddExpand("latest_invoice.payment_intent")
+	return sub.New(params)
+}
+
+// CancelSubscription cancels a Subscription at the end of the current period
+func CancelSubscription(subscriptionID string) (*stripe.Subscription, error) {
+	params := &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(true),
+	}
+	return sub.Update(subscriptionID, params)
+}
+
+// RefundPaymentIntent refunds amount of a PaymentIntent's charge. Calls
+// repeating idempotencyKey return the first refund instead of a new one.
+func RefundPaymentIntent(paymentIntentID string, amount int64, idempotencyKey string) (*stripe.Refund, error) {
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(paymentIntentID),
+		Amount:        stripe.Int64(amount),
+	}
+	params.SetIdempotencyKey(idempotencyKey)
+	return refund.New(params)
+}
+
+// CheckoutItem is a single line shown on a hosted Checkout page.
+type CheckoutItem struct {
+	Name   string
+	Amount int64
+}
+
+// CreateCheckoutSession creates a hosted Checkout Session in payment mode.
+// metadata is copied onto the underlying PaymentIntent so the
+// payment_intent.* webhooks see the same purpose as the session.
+func CreateCheckoutSession(reference string, items []CheckoutItem, currency, successURL, cancelURL string, metadata map[string]string) (*stripe.CheckoutSession, error) {
+	params := &stripe.CheckoutSessionParams{
+		Mode:               stripe.String(string(stripe.CheckoutSessionModePayment)),
+		PaymentMethodTypes: stripe.StringSlice([]string{"card"}),
+		ClientReferenceID:  stripe.String(reference),
+		SuccessURL:         stripe.String(successURL),
+		CancelURL:          stripe.String(cancelURL),
+		PaymentIntentData: &stripe.CheckoutSessionPaymentIntentDataParams{
+			Metadata: metadata,
+		},
+	}
+	params.Metadata = metadata
+	for _, it := range items {
+		params.LineItems = append(params.LineItems, &stripe.CheckoutSessionLineItemParams{
+			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+				Currency:   stripe.String(currency),
+				UnitAmount: stripe.Int64(it.Amount),
+				ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+					Name: stripe.String(it.Name),
+				},
+			},
+			Quantity: stripe.Int64(1),
+		})
+	}
+	return session.New(params)
+}
+
+// GetCheckoutSession fetches a Checkout Session
+func GetCheckoutSession(id string) (*stripe.CheckoutSession, error) {
+	return session.Get(id, nil)
+}
+
+// ExpireCheckoutSession expires an open Checkout Session so it can no
+// longer be paid
+func ExpireCheckoutSession(id string) (*stripe.CheckoutSession, error) {
+	return session.Expire(id, nil)
+}
+
+// SessionState reports where s stands as far as money is concerned.
+func SessionState(s *stripe.CheckoutSession) string {
+	switch s.Status {
+	case stripe.CheckoutSessionStatusOpen:
+		return PaymentOpen
+	case stripe.CheckoutSessionStatusExpired:
+		return PaymentClosed
+	}
+	return PaymentTaken
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// AgentCommandHandler handles HTTP requests for the PC command queue, from
+// agents and from staff.
+type AgentCommandHandler struct {
+	uc usecase.AgentCommandUseCase
+}
+
+// NewAgentCommandHandler creates a new AgentCommandHandler with injected use case.
+func NewAgentCommandHandler(uc usecase.AgentCommandUseCase) *AgentCommandHandler {
+	return &AgentCommandHandler{uc: uc}
+}
+
+// PollCommands returns the agent's due commands. With ?wait=25s it holds
+// the request until a command is due or the wait ends.
+func (h *AgentCommandHandler) PollCommands(c *gin.Context) {
+	var wait time.Duration
+	if s := c.Query("wait"); s != "" {
+		var err error
+		if wait, err = time.ParseDuration(s); err != nil || wait < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid wait"})
+			return
+		}
+	}
+	comp := c.MustGet("computer").(*entities.Computer)
+	list, err := h.uc.Poll(c.Request.Context(), comp, wait)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// AckCommand records the outcome of a command; a non-empty error marks it
+// failed.
+func (h *AgentCommandHandler) AckCommand(c *gin.Context) {
+	var req struct {
+		Error string `json:"error"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	comp := c.MustGet("computer").(*entities.Computer)
+	cmd, err := h.uc.Ack(c.Request.Context(), comp, c.Param("id"), req.Error)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cmd)
+}
+
+func (h *AgentCommandHandler) SendCommand(c *gin.Context) {
+	var req struct {
+		Kind    string `json:"kind" binding:"required"`
+		Message string `json:"message"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cmd, err := h.uc.Send(c.Request.Context(), c.GetString("uid"), c.Param("id"), req.Kind, req.Message)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, cmd)
+}
+
+func (h *AgentCommandHandler) GetCommands(c *gin.Context) {
+	list, err := h.uc.History(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+	"main/internal/interfaces/http/middleware"
+)
+
+// AgentHandler handles HTTP requests from PC agents and for the alerts
+// raised from them.
+type AgentHandler struct {
+	uc usecase.AgentUseCase
+}
+
+// NewAgentHandler creates a new AgentHandler with injected use case.
+func NewAgentHandler(uc usecase.AgentUseCase) *AgentHandler {
+	return &AgentHandler{uc: uc}
+}
+
+// IssueToken returns a new agent API key for the computer. It is shown
+// only once and replaces the previous key.
+func (h *AgentHandler) IssueToken(c *gin.Context) {
+	token, err := h.uc.IssueToken(c.Request.Context(), c.GetString("uid"), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"computer_id": c.Param("id"), "token": token})
+}
+
+// Heartbeat is called by the agent every few seconds; the computer comes
+// from AgentMiddleware.
+func (h *AgentHandler) Heartbeat(c *gin.Context) {
+	var hb entities.Heartbeat
+	if err := c.ShouldBindJSON(&hb); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	comp := c.MustGet("computer").(*entities.Computer)
+	st, err := h.uc.Heartbeat(c.Request.Context(), comp, hb)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, st)
+}
+
+// GetClubAlerts lists a club's open alerts, newest first.
+func (h *AgentHandler) GetClubAlerts(c *gin.Context) {
+	list, err := h.uc.Alerts(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *AgentHandler) ResolveAlert(c *gin.Context) {
+	a, err := h.uc.ResolveAlert(c.Request.Context(), middleware.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
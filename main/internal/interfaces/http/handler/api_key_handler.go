@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// APIKeyHandler handles HTTP requests for club API keys.
+type APIKeyHandler struct {
+	uc usecase.APIKeyUseCase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler with injected use case.
+func NewAPIKeyHandler(uc usecase.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{uc: uc}
+}
+
+// CreateAPIKey issues a key for the club. The response carries the key
+// itself, which is not shown again.
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req struct {
+		Name       string    `json:"name" binding:"required"`
+		Scopes     []string  `json:"scopes" binding:"required"`
+		ComputerID string    `json:"computer_id"`
+		ExpiresAt  time.Time `json:"expires_at"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	k, raw, err := h.uc.Create(c.Request.Context(), c.GetString("uid"), &entities.APIKey{
+		ClubID:     c.Param("id"),
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		ComputerID: req.ComputerID,
+		ExpiresAt:  req.ExpiresAt,
+	})
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"api_key": k, "key": raw})
+}
+
+func (h *APIKeyHandler) GetClubAPIKeys(c *gin.Context) {
+	list, err := h.uc.List(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// RotateAPIKey issues a replacement key; the old one expires after a grace
+// period.
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	k, raw, err := h.uc.Rotate(c.Request.Context(), c.GetString("uid"), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"api_key": k, "key": raw})
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	k, err := h.uc.Revoke(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, k)
+}
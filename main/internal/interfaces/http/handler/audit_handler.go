@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// AuditHandler handles HTTP requests for the audit log.
+type AuditHandler struct {
+	uc usecase.AuditUseCase
+}
+
+// NewAuditHandler creates a new AuditHandler with injected use case.
+func NewAuditHandler(uc usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{uc: uc}
+}
+
+// GetAuditLog searches the audit log, e.g.
+// ?entity_type=booking&entity_id=abc or ?actor=uid&from=2026-01-01T00:00:00Z.
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	f, err := parseAuditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := h.uc.Search(c.Request.Context(), f)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// parseAuditFilter reads actor, key_id, action, entity_type, entity_id,
+// from and to (RFC 3339), cursor and limit.
+func parseAuditFilter(c *gin.Context) (entities.AuditFilter, error) {
+	f := entities.AuditFilter{
+		ActorUID:   c.Query("actor"),
+		KeyID:      c.Query("key_id"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		Cursor:     c.Query("cursor"),
+	}
+	var err error
+	if f.Limit, err = intQuery(c, "limit"); err != nil {
+		return f, err
+	}
+	if f.From, err = timeQuery(c, "from"); err != nil {
+		return f, err
+	}
+	if f.To, err = timeQuery(c, "to"); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+func timeQuery(c *gin.Context, key string) (time.Time, error) {
+	s := c.Query(key)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be RFC 3339", entities.ErrInvalidAuditFilter, key)
+	}
+	return t, nil
+}
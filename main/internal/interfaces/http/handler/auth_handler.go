@@ -7,16 +7,19 @@ import (
 
 	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
 )
 
 // AuthHandler handles authentication requests.
 type AuthHandler struct {
 	authClient *auth.Client
+	userUC     usecase.UserUseCase
 }
 
 // NewAuthHandler creates a new AuthHandler with injected Firebase Auth client.
-func NewAuthHandler(client *auth.Client) *AuthHandler {
-	return &AuthHandler{authClient: client}
+func NewAuthHandler(client *auth.Client, userUC usecase.UserUseCase) *AuthHandler {
+	return &AuthHandler{authClient: client, userUC: userUC}
 }
 
 func (h *AuthHandler) Auth(c *gin.Context) {
@@ -40,8 +43,20 @@ func (h *AuthHandler) Auth(c *gin.Context) {
 		return
 	}
 
+	// the first login creates the profile from what Firebase knows
+	seed := &entities.User{ID: decodedToken.UID}
+	seed.DisplayName, _ = decodedToken.Claims["name"].(string)
+	seed.Email, _ = decodedToken.Claims["email"].(string)
+	seed.Phone, _ = decodedToken.Claims["phone_number"].(string)
+	user, err := h.userUC.Ensure(c.Request.Context(), seed)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Successful login",
 		"uid":     decodedToken.UID,
+		"user":    user,
 	})
 }
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+	"main/internal/interfaces/http/middleware"
+)
+
+// BanHandler handles HTTP requests for user bans.
+type BanHandler struct {
+	uc usecase.BanUseCase
+}
+
+// NewBanHandler creates a new BanHandler with injected use case.
+func NewBanHandler(uc usecase.BanUseCase) *BanHandler {
+	return &BanHandler{uc: uc}
+}
+
+// CreateBan bans a user at a club, or everywhere when club_id is empty,
+// which only admins may do. Staff ban at their own club only.
+func (h *BanHandler) CreateBan(c *gin.Context) {
+	var req struct {
+		UserID    string    `json:"user_id"`
+		ClubID    string    `json:"club_id"`
+		Reason    string    `json:"reason"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ban, err := h.uc.Ban(c.Request.Context(), middleware.CurrentPrincipal(c), &entities.Ban{
+		UserID:    req.UserID,
+		ClubID:    req.ClubID,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, ban)
+}
+
+// LiftBan ends a ban before it expires.
+func (h *BanHandler) LiftBan(c *gin.Context) {
+	ban, err := h.uc.Lift(c.Request.Context(), middleware.CurrentPrincipal(c), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ban)
+}
+
+// GetClubBans lists the bans in force at a club.
+func (h *BanHandler) GetClubBans(c *gin.Context) {
+	h.listActive(c, c.Param("id"))
+}
+
+// GetGlobalBans lists the platform-wide bans in force.
+func (h *BanHandler) GetGlobalBans(c *gin.Context) {
+	h.listActive(c, "")
+}
+
+func (h *BanHandler) listActive(c *gin.Context, clubID string) {
+	list, err := h.uc.ClubBans(c.Request.Context(), clubID)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetUserBans returns a user's ban history.
+func (h *BanHandler) GetUserBans(c *gin.Context) {
+	list, err := h.uc.UserBans(c.Request.Context(), c.Param("uid"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.Ban, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
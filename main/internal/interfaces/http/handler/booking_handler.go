@@ -2,27 +2,25 @@ package handler
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"main/internal/application/usecase"
+	"main/internal/config"
 	"main/internal/domain/entities"
+	"main/internal/interfaces/http/middleware"
 )
 
 // BookingHandler handles HTTP requests for bookings.
 type BookingHandler struct {
 	bookingUC usecase.BookingUseCase
-	clubUC    usecase.ClubUseCase
 }
 
 // NewBookingHandler creates a new BookingHandler with injected use cases.
 func NewBookingHandler(
 	bookingUC usecase.BookingUseCase,
-	clubUC usecase.ClubUseCase,
 ) *BookingHandler {
 	return &BookingHandler{
 		bookingUC: bookingUC,
-		clubUC:    clubUC,
 	}
 }
 
@@ -49,8 +47,7 @@ func (h *BookingHandler) GetUserBookings(c *gin.Context) {
 	c.JSON(http.StatusOK, list)
 }
 
-func (h *BookingHandler) CreateBooking(c *gin.Context) {
-	// pull UID out of the context (set by AuthMiddleware)
+func (h *BookingHandler) QuoteBooking(c *gin.Context) {
 	uidIf, exists := c.Get("uid")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -58,42 +55,51 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 	}
 	userID, _ := uidIf.(string)
 
-	// bind request
-	var req struct {
-		ClubID    string    `json:"club_id"`
-		PCNumber  int       `json:"pc_number"`
-		StartTime time.Time `json:"start_time"`
-		EndTime   time.Time `json:"end_time"`
-	}
+	var req entities.QuoteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req.UserID = userID
 
-	// fetch club to get price per hour
-	club, err := h.clubUC.GetByID(c.Request.Context(), req.ClubID)
+	quote, err := h.bookingUC.Quote(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, quote)
+}
 
-	// calculate duration and total price
-	durationHours := req.EndTime.Sub(req.StartTime).Hours()
-	totalPrice := durationHours * club.PricePerHour
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	// pull UID out of the context (set by AuthMiddleware)
+	uidIf, exists := c.Get("uid")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, _ := uidIf.(string)
+
+	// bind request
+	var req entities.QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	// assemble booking entity
+	// assemble booking entity; pricing is done by the use case
 	booking := &entities.Booking{
 		ClubID:     req.ClubID,
 		UserID:     userID,
 		PCNumber:   req.PCNumber,
 		StartTime:  req.StartTime,
 		EndTime:    req.EndTime,
-		TotalPrice: totalPrice,
+		PromoCode:  req.PromoCode,
+		PointsUsed: req.RedeemPoints,
 	}
 
 	// create booking
 	if err := h.bookingUC.Create(c.Request.Context(), booking); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFor(err), errorBody(err))
 		return
 	}
 
@@ -102,9 +108,123 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 
 func (h *BookingHandler) CancelBooking(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.bookingUC.Cancel(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.bookingUC.Cancel(c.Request.Context(), c.GetString("uid"), id); err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (h *BookingHandler) PayWithWallet(c *gin.Context) {
+	id := c.Param("id")
+	booking, err := h.bookingUC.PayWithWallet(c.Request.Context(), c.GetString("uid"), id)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
+
+func (h *BookingHandler) CreatePaymentIntent(c *gin.Context) {
+	id := c.Param("id")
+	clientSecret, err := h.bookingUC.CreatePaymentIntent(c.Request.Context(), c.GetString("uid"), id)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"clientSecret": clientSecret})
+}
+
+// CreateCheckoutSession redirects to hosted Checkout as an alternative to
+// confirming a PaymentIntent client-side.
+func (h *BookingHandler) CreateCheckoutSession(c *gin.Context) {
+	id := c.Param("id")
+	url, err := h.bookingUC.CreateCheckoutSession(c.Request.Context(), c.GetString("uid"), id,
+		config.Cfg.Stripe.CheckoutSuccessURL, config.Cfg.Stripe.CheckoutCancelURL)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": url})
+}
+
+// inCallerClub refuses staff and front-desk API keys acting on another
+// club's booking; other users pass.
+func (h *BookingHandler) inCallerClub(c *gin.Context) bool {
+	p := middleware.CurrentPrincipal(c)
+	if p == nil || !p.ClubBound() {
+		return true
+	}
+	b, err := h.bookingUC.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return false
+	}
+	if !p.CanAccessClub(b.ClubID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not work at this club"})
+		return false
+	}
+	return true
+}
+
+// RecordOfflinePayment lets staff mark a booking as paid at the front desk.
+func (h *BookingHandler) RecordOfflinePayment(c *gin.Context) {
+	if !h.inCallerClub(c) {
+		return
+	}
+	var req struct {
+		Method    string  `json:"method" binding:"required"`
+		Reference string  `json:"reference"`
+		Amount    float64 `json:"amount" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	booking, err := h.bookingUC.RecordOfflinePayment(c.Request.Context(), c.GetString("uid"), c.Param("id"),
+		req.Method, req.Reference, req.Amount)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
+
+// CheckInBooking starts the session at the front desk and unlocks the PC.
+func (h *BookingHandler) CheckInBooking(c *gin.Context) {
+	if !h.inCallerClub(c) {
+		return
+	}
+	booking, err := h.bookingUC.CheckIn(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), errorBody(err))
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
+
+func (h *BookingHandler) CompleteBooking(c *gin.Context) {
+	if !h.inCallerClub(c) {
+		return
+	}
+	id := c.Param("id")
+	booking, err := h.bookingUC.Complete(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
+
+func (h *BookingHandler) RefundBooking(c *gin.Context) {
+	if !h.inCallerClub(c) {
+		return
+	}
+	id := c.Param("id")
+	booking, err := h.bookingUC.Refund(c.Request.Context(), c.GetString("uid"), id)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, booking)
+}
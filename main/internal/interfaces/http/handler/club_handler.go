@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"main/internal/application/usecase"
 	"main/internal/domain/entities"
+	"main/internal/domain/geo"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
+// defaultNearbyRadius applies when near is given without radius.
+const defaultNearbyRadius = 5000 // meters
+
 // ClubHandler handles HTTP requests for clubs.
 type ClubHandler struct {
 	uc usecase.ClubUseCase
@@ -17,13 +24,130 @@ func NewClubHandler(uc usecase.ClubUseCase) *ClubHandler {
 	return &ClubHandler{uc: uc}
 }
 
+// GetAllClubs searches clubs. Query parameters: city, min_price, max_price,
+// free_now, open_now, amenities (comma separated), min_gpu_class, near=lat,lng with
+// radius (e.g. 5km or 800m), sort (name, price, rating, distance), order
+// (asc, desc), cursor and limit.
 func (h *ClubHandler) GetAllClubs(c *gin.Context) {
-	clubs, err := h.uc.GetAll(c.Request.Context())
+	f, err := parseClubFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, clubs)
+	page, err := h.uc.Search(c.Request.Context(), f)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+func parseClubFilter(c *gin.Context) (entities.ClubFilter, error) {
+	f := entities.ClubFilter{
+		City:   c.Query("city"),
+		SortBy: c.DefaultQuery("sort", entities.ClubSortName),
+		Cursor: c.Query("cursor"),
+	}
+	var err error
+	if f.MinPrice, err = floatQuery(c, "min_price"); err != nil {
+		return f, err
+	}
+	if f.MaxPrice, err = floatQuery(c, "max_price"); err != nil {
+		return f, err
+	}
+	if f.MinGPUClass, err = intQuery(c, "min_gpu_class"); err != nil {
+		return f, err
+	}
+	if f.Limit, err = intQuery(c, "limit"); err != nil {
+		return f, err
+	}
+	f.HasFreePCs = c.Query("free_now") == "true"
+	f.OpenNow = c.Query("open_now") == "true"
+	if s := c.Query("amenities"); s != "" {
+		f.Amenities = strings.Split(s, ",")
+	}
+	if near := c.Query("near"); near != "" {
+		center, err := parsePoint(near)
+		if err != nil {
+			return f, errors.New("invalid near")
+		}
+		f.Near = &center
+		f.Radius = defaultNearbyRadius
+		if s := c.Query("radius"); s != "" {
+			if f.Radius, err = parseDistance(s); err != nil {
+				return f, errors.New("invalid radius")
+			}
+		}
+		if c.Query("sort") == "" {
+			f.SortBy = entities.ClubSortDistance
+		}
+	}
+	switch c.Query("order") {
+	case "desc":
+		f.Desc = true
+	case "":
+		// best rated first unless asked otherwise
+		f.Desc = f.SortBy == entities.ClubSortRating
+	}
+	return f, nil
+}
+
+func floatQuery(c *gin.Context, key string) (float64, error) {
+	s := c.Query(key)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.New("invalid " + key)
+	}
+	return v, nil
+}
+
+func intQuery(c *gin.Context, key string) (int, error) {
+	s := c.Query(key)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("invalid " + key)
+	}
+	return v, nil
+}
+
+// parsePoint parses "lat,lng".
+func parsePoint(s string) (geo.Point, error) {
+	lat, lng, ok := strings.Cut(s, ",")
+	if !ok {
+		return geo.Point{}, errors.New("expected lat,lng")
+	}
+	var p geo.Point
+	var err error
+	if p.Lat, err = strconv.ParseFloat(strings.TrimSpace(lat), 64); err != nil {
+		return geo.Point{}, err
+	}
+	if p.Lng, err = strconv.ParseFloat(strings.TrimSpace(lng), 64); err != nil {
+		return geo.Point{}, err
+	}
+	return p, nil
+}
+
+// parseDistance parses a distance in meters with an optional km or m
+// suffix; a bare number is kilometers.
+func parseDistance(s string) (float64, error) {
+	unit := 1000.0
+	switch {
+	case strings.HasSuffix(s, "km"):
+		s = strings.TrimSuffix(s, "km")
+	case strings.HasSuffix(s, "m"):
+		s, unit = strings.TrimSuffix(s, "m"), 1
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * unit, nil
 }
 
 func (h *ClubHandler) GetClubByID(c *gin.Context) {
@@ -42,8 +166,9 @@ func (h *ClubHandler) CreateClub(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	in.OwnerID = c.GetString("uid")
 	if err := h.uc.Create(c.Request.Context(), &in); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, in)
@@ -58,17 +183,137 @@ func (h *ClubHandler) UpdateClub(c *gin.Context) {
 	}
 	in.ID = id
 	if err := h.uc.Update(c.Request.Context(), &in); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
+// SetHours replaces the club's opening hours, exceptions and closures.
+func (h *ClubHandler) SetHours(c *gin.Context) {
+	var in entities.OpeningHours
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	club, err := h.uc.SetHours(c.Request.Context(), c.Param("id"), &in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+// SetAgeRestriction replaces the hours during which the club admits only
+// guests with a verified age.
+func (h *ClubHandler) SetAgeRestriction(c *gin.Context) {
+	var in entities.AgeRestriction
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	club, err := h.uc.SetAgeRestriction(c.Request.Context(), c.Param("id"), &in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+// DeleteAgeRestriction lifts the club's age-restricted hours.
+func (h *ClubHandler) DeleteAgeRestriction(c *gin.Context) {
+	club, err := h.uc.SetAgeRestriction(c.Request.Context(), c.Param("id"), nil)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+func (h *ClubHandler) AddClosure(c *gin.Context) {
+	var in entities.Closure
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	club, err := h.uc.AddClosure(c.Request.Context(), c.Param("id"), in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+// DeleteClub archives a club. Upcoming paid bookings block it unless
+// ?cancel_bookings=true, which cancels and refunds them.
 func (h *ClubHandler) DeleteClub(c *gin.Context) {
-	id := c.Param("id")
-	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	cancel := c.Query("cancel_bookings") == "true"
+	del, err := h.uc.Delete(c.Request.Context(), c.GetString("uid"), c.Param("id"), cancel)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
 		return
 	}
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, del)
+}
+
+// GetDeletedClubs lists archived clubs that can still be restored.
+func (h *ClubHandler) GetDeletedClubs(c *gin.Context) {
+	list, err := h.uc.Deleted(c.Request.Context())
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// RestoreClub brings an archived club back.
+func (h *ClubHandler) RestoreClub(c *gin.Context) {
+	club, err := h.uc.Restore(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+// SetClubOwner hands a club to another owner.
+func (h *ClubHandler) SetClubOwner(c *gin.Context) {
+	var req struct {
+		OwnerID string `json:"owner_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	club, err := h.uc.SetOwner(c.Request.Context(), c.Param("id"), req.OwnerID)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+// SetNoShowPolicy turns on automatic bans after repeated no-shows.
+func (h *ClubHandler) SetNoShowPolicy(c *gin.Context) {
+	var in entities.NoShowPolicy
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	club, err := h.uc.SetNoShowPolicy(c.Request.Context(), c.Param("id"), &in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
+}
+
+// DeleteNoShowPolicy turns off automatic no-show bans; existing bans stay.
+func (h *ClubHandler) DeleteNoShowPolicy(c *gin.Context) {
+	club, err := h.uc.SetNoShowPolicy(c.Request.Context(), c.Param("id"), nil)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, club)
 }
@@ -5,6 +5,7 @@ import (
 	"main/internal/application/usecase"
 	"main/internal/domain/entities"
 	"net/http"
+	"time"
 )
 
 // ComputerHandler handles HTTP requests for computers.
@@ -17,38 +18,151 @@ func NewComputerHandler(uc usecase.ComputerUseCase) *ComputerHandler {
 	return &ComputerHandler{uc: uc}
 }
 
+// GetAllComputers searches computers across clubs; see parseComputerFilter.
 func (h *ComputerHandler) GetAllComputers(c *gin.Context) {
-	comps, err := h.uc.GetAll(c.Request.Context())
+	h.search(c, c.Query("club_id"))
+}
+
+// GetClubComputers lists the matching computers of one club as an array,
+// e.g. ?gpu=rtx4070&min_hz=240. Passing cursor or limit opts in to pages.
+func (h *ComputerHandler) GetClubComputers(c *gin.Context) {
+	if c.Query("cursor") != "" || c.Query("limit") != "" {
+		h.search(c, c.Param("id"))
+		return
+	}
+	f, err := parseComputerFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, comps)
+	f.ClubID = c.Param("id")
+	list, err := h.uc.SearchAll(c.Request.Context(), f)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
 }
 
-func (h *ComputerHandler) GetClubComputers(c *gin.Context) {
-	clubID := c.Param("id")
-	comps, err := h.uc.GetByClub(c.Request.Context(), clubID)
+func (h *ComputerHandler) search(c *gin.Context, clubID string) {
+	f, err := parseComputerFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, comps)
+	f.ClubID = clubID
+	page, err := h.uc.Search(c.Request.Context(), f)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// parseComputerFilter reads category, gpu, min_hz, min_ram, available,
+// min_gpu_class, cursor and limit.
+func parseComputerFilter(c *gin.Context) (entities.ComputerFilter, error) {
+	f := entities.ComputerFilter{
+		Category: c.Query("category"),
+		GPU:      c.Query("gpu"),
+		Cursor:   c.Query("cursor"),
+	}
+	var err error
+	if f.MinHz, err = intQuery(c, "min_hz"); err != nil {
+		return f, err
+	}
+	if f.MinRAMGB, err = intQuery(c, "min_ram"); err != nil {
+		return f, err
+	}
+	if f.MinGPUClass, err = intQuery(c, "min_gpu_class"); err != nil {
+		return f, err
+	}
+	if f.Limit, err = intQuery(c, "limit"); err != nil {
+		return f, err
+	}
+	if f.Category != "" && !entities.IsValidCategory(f.Category) {
+		return f, entities.ErrInvalidCategory
+	}
+	if s := c.Query("available"); s != "" {
+		available := s == "true"
+		f.Available = &available
+	}
+	return f, nil
+}
+
+func (h *ComputerHandler) GetComputer(c *gin.Context) {
+	comp, err := h.uc.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comp)
+}
+
+func (h *ComputerHandler) UpdateComputer(c *gin.Context) {
+	var in entities.Computer
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.ID = c.Param("id")
+	comp, err := h.uc.Update(c.Request.Context(), &in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comp)
+}
+
+// DeleteComputer soft-deletes a computer and reports the bookings moved off it.
+func (h *ComputerHandler) DeleteComputer(c *gin.Context) {
+	rep, err := h.uc.Delete(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
+
+// StartMaintenance takes a computer out of service; expected_back is optional.
+func (h *ComputerHandler) StartMaintenance(c *gin.Context) {
+	var req struct {
+		Reason       string    `json:"reason" binding:"required"`
+		ExpectedBack time.Time `json:"expected_back"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rep, err := h.uc.StartMaintenance(c.Request.Context(), c.GetString("uid"), c.Param("id"), req.Reason, req.ExpectedBack)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
+
+func (h *ComputerHandler) EndMaintenance(c *gin.Context) {
+	comp, err := h.uc.EndMaintenance(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comp)
 }
 
 func (h *ComputerHandler) CreateComputerList(c *gin.Context) {
-	clubID := c.Param("id")
 	var list []entities.Computer
 	if err := c.ShouldBindJSON(&list); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	for i := range list {
-		list[i].ClubID = clubID
-		if err := h.uc.Create(c.Request.Context(), &list[i]); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+	rep, ok := h.importRows(c, importRowsFromList(list), false)
+	if !ok {
+		return
+	}
+	if rep.Computers == nil {
+		rep.Computers = make([]*entities.Computer, 0)
 	}
-	c.JSON(http.StatusCreated, list)
+	c.JSON(http.StatusCreated, rep.Computers)
 }
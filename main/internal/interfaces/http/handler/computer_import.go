@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/domain/entities"
+)
+
+// csvColumns are the columns a computer CSV may have; pc_number is required.
+// peripherals are separated by semicolons and is_available defaults to true.
+var csvColumns = map[string]bool{
+	"pc_number": true, "description": true, "category": true, "gpu": true, "cpu": true,
+	"ram_gb": true, "monitor_hz": true, "gpu_class": true, "peripherals": true, "is_available": true,
+}
+
+// ImportComputers creates a club's computers from a JSON array or, with
+// Content-Type text/csv, a CSV with a header row. With ?dry_run=true it only
+// returns the per-row validation report.
+func (h *ComputerHandler) ImportComputers(c *gin.Context) {
+	var rows []entities.ImportRow
+	if c.ContentType() == "text/csv" {
+		var err error
+		if rows, err = parseComputerCSV(c.Request.Body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		var list []entities.Computer
+		if err := c.ShouldBindJSON(&list); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rows = importRowsFromList(list)
+	}
+	dryRun := c.Query("dry_run") == "true"
+	rep, ok := h.importRows(c, rows, dryRun)
+	if !ok {
+		return
+	}
+	if dryRun {
+		c.JSON(http.StatusOK, rep)
+		return
+	}
+	c.JSON(http.StatusCreated, rep)
+}
+
+// importRows runs the import and writes the error response if it failed;
+// a report with invalid rows is returned as 422.
+func (h *ComputerHandler) importRows(c *gin.Context, rows []entities.ImportRow, dryRun bool) (*entities.ImportReport, bool) {
+	rep, err := h.uc.Import(c.Request.Context(), c.Param("id"), rows, dryRun)
+	switch {
+	case errors.Is(err, entities.ErrImportInvalid) && dryRun:
+		return rep, true
+	case errors.Is(err, entities.ErrImportInvalid):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "report": rep})
+		return nil, false
+	case err != nil:
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return rep, true
+}
+
+func importRowsFromList(list []entities.Computer) []entities.ImportRow {
+	rows := make([]entities.ImportRow, len(list))
+	for i, comp := range list {
+		rows[i] = entities.ImportRow{Row: i + 1, Computer: comp}
+	}
+	return rows
+}
+
+// parseComputerCSV decodes a computer CSV. Malformed values are reported on
+// their row; only an unreadable file or a bad header fails the whole body.
+func parseComputerCSV(r io.Reader) ([]entities.ImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		cols[name] = i
+	}
+	if _, ok := cols["pc_number"]; !ok {
+		return nil, errors.New("csv has no pc_number column")
+	}
+
+	var rows []entities.ImportRow
+	for n := 1; ; n++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("reading csv row %d: %w", n, err)
+		}
+		row := entities.ImportRow{Row: n, Computer: entities.Computer{IsAvailable: true}}
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+			rows = append(rows, row)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := cols[name]; ok {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		number := func(name string) int {
+			s := field(name)
+			if s == "" {
+				return 0
+			}
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s: not a number: %q", name, s))
+			}
+			return v
+		}
+		comp := &row.Computer
+		comp.PCNumber = number("pc_number")
+		comp.Description = field("description")
+		comp.Category = field("category")
+		comp.GPUClass = number("gpu_class")
+		comp.Specs.GPU = field("gpu")
+		comp.Specs.CPU = field("cpu")
+		comp.Specs.RAMGB = number("ram_gb")
+		comp.Specs.MonitorHz = number("monitor_hz")
+		for _, p := range strings.Split(field("peripherals"), ";") {
+			if p = strings.TrimSpace(p); p != "" {
+				comp.Specs.Peripherals = append(comp.Specs.Peripherals, p)
+			}
+		}
+		if s := field("is_available"); s != "" {
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("is_available: not a boolean: %q", s))
+			}
+			comp.IsAvailable = v
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// statusFor maps known domain errors to HTTP status codes.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrPromoCodeNotFound),
+		errors.Is(err, entities.ErrPromoCodeInactive),
+		errors.Is(err, entities.ErrPromoCodeExpired),
+		errors.Is(err, entities.ErrPromoCodeWrongClub),
+		errors.Is(err, entities.ErrPromoCodeMinSpend),
+		errors.Is(err, entities.ErrPromoCodeFirstBooking),
+		errors.Is(err, usecase.ErrInvalidInterval),
+		errors.Is(err, entities.ErrBookingTooLong),
+		errors.Is(err, usecase.ErrInvalidClub),
+		errors.Is(err, usecase.ErrUnknownComputer),
+		errors.Is(err, entities.ErrInvalidCategory),
+		errors.Is(err, entities.ErrInvalidMaintenance),
+		errors.Is(err, entities.ErrInvalidPCNumber),
+		errors.Is(err, entities.ErrMissingSpecs),
+		errors.Is(err, entities.ErrImportTooLarge),
+		errors.Is(err, entities.ErrInvalidFloorPlan),
+		errors.Is(err, entities.ErrInvalidCommand),
+		errors.Is(err, entities.ErrInvalidScope),
+		errors.Is(err, entities.ErrInvalidAPIKey),
+		errors.Is(err, entities.ErrInvalidProfile),
+		errors.Is(err, entities.ErrInvalidAgeRestriction),
+		errors.Is(err, entities.ErrInvalidVerification),
+		errors.Is(err, entities.ErrInvalidBan),
+		errors.Is(err, entities.ErrInvalidNoShowRule),
+		errors.Is(err, entities.ErrInvalidAuditFilter),
+		errors.Is(err, entities.ErrInvalidRole),
+		errors.Is(err, entities.ErrStaffClub),
+		errors.Is(err, usecase.ErrInvalidLocation),
+		errors.Is(err, entities.ErrInvalidHours),
+		errors.Is(err, entities.ErrInvalidTimezone),
+		errors.Is(err, entities.ErrInvalidTaxRate),
+		errors.Is(err, entities.ErrClubClosed),
+		errors.Is(err, entities.ErrInvalidCursor),
+		errors.Is(err, usecase.ErrInvalidAmount),
+		errors.Is(err, entities.ErrPaymentAmount),
+		errors.Is(err, entities.ErrPointsNotAccepted),
+		errors.Is(err, entities.ErrPaymentMethod),
+		errors.Is(err, entities.ErrPaymentReference):
+		return http.StatusBadRequest
+	case errors.Is(err, entities.ErrBookingNotOwned),
+		errors.Is(err, entities.ErrAgeNotVerified),
+		errors.Is(err, entities.ErrUnderage),
+		errors.Is(err, entities.ErrUserBanned),
+		errors.Is(err, entities.ErrGlobalBanRequired),
+		errors.Is(err, entities.ErrBanOtherClub),
+		errors.Is(err, entities.ErrAlertOtherClub),
+		errors.Is(err, entities.ErrInvoiceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, entities.ErrComputerNotFound),
+		errors.Is(err, entities.ErrFloorPlanNotFound),
+		errors.Is(err, entities.ErrAlertNotFound),
+		errors.Is(err, entities.ErrCommandNotFound),
+		errors.Is(err, entities.ErrAPIKeyNotFound),
+		errors.Is(err, entities.ErrUserNotFound),
+		errors.Is(err, entities.ErrBanNotFound),
+		errors.Is(err, entities.ErrInvoiceNotFound),
+		errors.Is(err, entities.ErrClubNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrPromoCodeExhausted),
+		errors.Is(err, entities.ErrPromoCodeUserLimit),
+		errors.Is(err, entities.ErrBookingNotPayable),
+		errors.Is(err, entities.ErrBookingTransition),
+		errors.Is(err, entities.ErrNotInMaintenance),
+		errors.Is(err, entities.ErrComputerInService),
+		errors.Is(err, entities.ErrDuplicatePCNumber),
+		errors.Is(err, entities.ErrCommandClosed),
+		errors.Is(err, entities.ErrCheckInWindow),
+		errors.Is(err, entities.ErrBookingStarted),
+		errors.Is(err, entities.ErrPaymentInProgress),
+		errors.Is(err, entities.ErrRefundAtDesk),
+		errors.Is(err, entities.ErrComputerBooked),
+		errors.Is(err, entities.ErrAPIKeyRevoked),
+		errors.Is(err, entities.ErrClubHasBookings),
+		errors.Is(err, entities.ErrInsufficientFunds),
+		errors.Is(err, entities.ErrInsufficientPoints),
+		errors.Is(err, entities.ErrMembershipHoursExhausted):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrImportInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+// errorBody is the response to a failed request. Errors clients must tell
+// apart also carry a machine-readable code.
+func errorBody(err error) gin.H {
+	body := gin.H{"error": err.Error()}
+	if errors.Is(err, entities.ErrUserBanned) {
+		body["code"] = "user_banned"
+	}
+	return body
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// FloorPlanHandler handles HTTP requests for club floor plans.
+type FloorPlanHandler struct {
+	uc     usecase.FloorPlanUseCase
+	clubUC usecase.ClubUseCase
+}
+
+// NewFloorPlanHandler creates a new FloorPlanHandler with injected use cases.
+func NewFloorPlanHandler(uc usecase.FloorPlanUseCase, clubUC usecase.ClubUseCase) *FloorPlanHandler {
+	return &FloorPlanHandler{uc: uc, clubUC: clubUC}
+}
+
+func (h *FloorPlanHandler) GetFloorPlan(c *gin.Context) {
+	plan, err := h.uc.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *FloorPlanHandler) SaveFloorPlan(c *gin.Context) {
+	var in entities.FloorPlan
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.ClubID = c.Param("id")
+	plan, err := h.uc.Save(c.Request.Context(), &in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *FloorPlanHandler) DeleteFloorPlan(c *gin.Context) {
+	if err := h.uc.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSeatMap returns the floor plan with seat statuses between from and to,
+// RFC 3339 timestamps or YYYY-MM-DD dates in the club's time zone. They
+// default to now and one hour from now.
+func (h *FloorPlanHandler) GetSeatMap(c *gin.Context) {
+	club, err := h.clubUC.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "club not found"})
+		return
+	}
+	from := time.Now()
+	to := from.Add(time.Hour)
+	if s := c.Query("from"); s != "" {
+		if from, err = parseTimeParam(s, club.TZ()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+			return
+		}
+		if c.Query("to") == "" {
+			to = from.Add(time.Hour)
+		}
+	}
+	if s := c.Query("to"); s != "" {
+		if to, err = parseTimeParam(s, club.TZ()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+			return
+		}
+	}
+	m, err := h.uc.SeatMap(c.Request.Context(), club.ID, from, to)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/interfaces/http/middleware"
+)
+
+// InvoiceHandler handles HTTP requests for booking receipts.
+type InvoiceHandler struct {
+	uc usecase.InvoiceUseCase
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler with injected use case.
+func NewInvoiceHandler(uc usecase.InvoiceUseCase) *InvoiceHandler {
+	return &InvoiceHandler{uc: uc}
+}
+
+// GetReceipt returns the booking's invoice as JSON, or as a PDF when
+// requested with ?format=pdf or Accept: application/pdf.
+func (h *InvoiceHandler) GetReceipt(c *gin.Context) {
+	id := c.Param("id")
+	inv, err := h.uc.GetByBooking(c.Request.Context(), middleware.CurrentPrincipal(c), id)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "pdf" || c.GetHeader("Accept") == "application/pdf" {
+		c.Header("Content-Disposition", `inline; filename="`+inv.Number+`.pdf"`)
+		c.Data(http.StatusOK, "application/pdf", h.uc.RenderPDF(inv))
+		return
+	}
+	c.JSON(http.StatusOK, inv)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// LoyaltyHandler handles HTTP requests for loyalty points.
+type LoyaltyHandler struct {
+	uc usecase.LoyaltyUseCase
+}
+
+// NewLoyaltyHandler creates a new LoyaltyHandler with injected use case.
+func NewLoyaltyHandler(uc usecase.LoyaltyUseCase) *LoyaltyHandler {
+	return &LoyaltyHandler{uc: uc}
+}
+
+// GetAccounts lists the caller's balance at each club.
+func (h *LoyaltyHandler) GetAccounts(c *gin.Context) {
+	list, err := h.uc.GetAccounts(c.Request.Context(), c.GetString("uid"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.LoyaltyAccount, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetClubAccount returns the caller's balance at the club.
+func (h *LoyaltyHandler) GetClubAccount(c *gin.Context) {
+	acc, err := h.uc.GetAccount(c.Request.Context(), c.GetString("uid"), c.Param("id"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, acc)
+}
+
+func (h *LoyaltyHandler) GetTransactions(c *gin.Context) {
+	list, err := h.uc.GetTransactions(c.Request.Context(), c.GetString("uid"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.LoyaltyTransaction, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *LoyaltyHandler) GetClubConfig(c *gin.Context) {
+	cfg, err := h.uc.GetConfig(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
+
+func (h *LoyaltyHandler) UpdateClubConfig(c *gin.Context) {
+	var in entities.LoyaltyConfig
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.ClubID = c.Param("id")
+	if err := h.uc.SaveConfig(c.Request.Context(), &in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, in)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// MembershipHandler handles HTTP requests for membership plans and subscriptions.
+type MembershipHandler struct {
+	uc usecase.MembershipUseCase
+}
+
+// NewMembershipHandler creates a new MembershipHandler with injected use case.
+func NewMembershipHandler(uc usecase.MembershipUseCase) *MembershipHandler {
+	return &MembershipHandler{uc: uc}
+}
+
+func (h *MembershipHandler) GetClubPlans(c *gin.Context) {
+	clubID := c.Param("id")
+	plans, err := h.uc.GetPlans(c.Request.Context(), clubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if plans == nil {
+		plans = make([]*entities.MembershipPlan, 0)
+	}
+	c.JSON(http.StatusOK, plans)
+}
+
+func (h *MembershipHandler) CreatePlan(c *gin.Context) {
+	var in entities.MembershipPlan
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.ClubID = c.Param("id")
+	if err := h.uc.CreatePlan(c.Request.Context(), &in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, in)
+}
+
+func (h *MembershipHandler) UpdatePlan(c *gin.Context) {
+	var in entities.MembershipPlan
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.ID = c.Param("planId")
+	in.ClubID = c.Param("id")
+	if err := h.uc.UpdatePlan(c.Request.Context(), &in); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, entities.ErrPlanNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *MembershipHandler) GetUserMemberships(c *gin.Context) {
+	list, err := h.uc.GetByUser(c.Request.Context(), c.GetString("uid"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.Membership, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *MembershipHandler) Subscribe(c *gin.Context) {
+	planID := c.Param("id")
+	m, clientSecret, err := h.uc.Subscribe(c.Request.Context(), c.GetString("uid"), planID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"membership": m, "clientSecret": clientSecret})
+}
+
+func (h *MembershipHandler) CancelMembership(c *gin.Context) {
+	id := c.Param("id")
+	m, err := h.uc.Cancel(c.Request.Context(), c.GetString("uid"), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"encoding/json"
 	"main/internal/config"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stripe/stripe-go/v72"
 	"github.com/stripe/stripe-go/v72/webhook"
 	"main/internal/application/usecase"
 )
@@ -48,7 +50,85 @@ func (h *PaymentHandler) Webhook(c *gin.Context) {
 	}
 	switch event.Type {
 	case "payment_intent.succeeded":
-		// handle success
+		var pi stripe.PaymentIntent
+		if err := json.Unmarshal(event.Data.Raw, &pi); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		// a non-2xx response makes Stripe retry the delivery
+		if err := h.uc.HandleIntentSucceeded(c.Request.Context(), pi.ID, pi.Amount, pi.Metadata); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	case "checkout.session.completed", "checkout.session.expired":
+		var cs stripe.CheckoutSession
+		if err := json.Unmarshal(event.Data.Raw, &cs); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if event.Type == "checkout.session.expired" {
+			err = h.uc.HandleCheckoutExpired(c.Request.Context(), cs.ID, cs.Metadata)
+		} else {
+			var intentID string
+			if cs.PaymentIntent != nil {
+				intentID = cs.PaymentIntent.ID
+			}
+			err = h.uc.HandleCheckoutCompleted(c.Request.Context(), cs.ID, intentID,
+				string(cs.PaymentStatus), cs.AmountTotal, string(cs.Currency), cs.Metadata)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	case "customer.subscription.created",
+		"customer.subscription.updated",
+		"customer.subscription.deleted":
+		var sub stripe.Subscription
+		if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := h.uc.HandleSubscriptionUpdated(c.Request.Context(), sub.ID, string(sub.Status),
+			sub.CurrentPeriodStart, sub.CurrentPeriodEnd, sub.CancelAtPeriodEnd); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	case "charge.succeeded":
+		var ch stripe.Charge
+		if err := json.Unmarshal(event.Data.Raw, &ch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var intentID, btID string
+		if ch.PaymentIntent != nil {
+			intentID = ch.PaymentIntent.ID
+		}
+		if ch.BalanceTransaction != nil {
+			btID = ch.BalanceTransaction.ID
+		}
+		if err := h.uc.HandleChargeSucceeded(c.Request.Context(), ch.ID, intentID, ch.Amount, string(ch.Currency), btID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	case "charge.refunded":
+		// refunds issued from the Stripe dashboard never pass through our API
+		var ch stripe.Charge
+		if err := json.Unmarshal(event.Data.Raw, &ch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		var intentID string
+		if ch.PaymentIntent != nil {
+			intentID = ch.PaymentIntent.ID
+		}
+		if ch.Refunds != nil {
+			for _, re := range ch.Refunds.Data {
+				if err := h.uc.HandleRefund(c.Request.Context(), re.ID, intentID, re.Amount, string(re.Currency)); err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+					return
+				}
+			}
+		}
 	case "payment_intent.payment_failed":
 		// handle failure
 	}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// PaymentLedgerHandler exposes the payments ledger and reconciliation reports to finance.
+type PaymentLedgerHandler struct {
+	uc usecase.PaymentLedgerUseCase
+}
+
+// NewPaymentLedgerHandler creates a new PaymentLedgerHandler with injected use case.
+func NewPaymentLedgerHandler(uc usecase.PaymentLedgerUseCase) *PaymentLedgerHandler {
+	return &PaymentLedgerHandler{uc: uc}
+}
+
+func (h *PaymentLedgerHandler) GetBookingEntries(c *gin.Context) {
+	list, err := h.uc.GetByBooking(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.PaymentLedgerEntry, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetReconciliation returns the stored report for a YYYY-MM-DD day.
+func (h *PaymentLedgerHandler) GetReconciliation(c *gin.Context) {
+	rep, err := h.uc.GetReconciliation(c.Request.Context(), c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
+
+// RunReconciliation reconciles the given day on demand, replacing any stored report.
+func (h *PaymentLedgerHandler) RunReconciliation(c *gin.Context) {
+	var req struct {
+		Date string `json:"date" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	day, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date"})
+		return
+	}
+	rep, err := h.uc.Reconcile(c.Request.Context(), day)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
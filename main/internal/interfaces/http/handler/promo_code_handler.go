@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+	"net/http"
+)
+
+// PromoCodeHandler handles admin HTTP requests for promo codes.
+type PromoCodeHandler struct {
+	uc usecase.PromoCodeUseCase
+}
+
+// NewPromoCodeHandler creates a new PromoCodeHandler with injected use case.
+func NewPromoCodeHandler(uc usecase.PromoCodeUseCase) *PromoCodeHandler {
+	return &PromoCodeHandler{uc: uc}
+}
+
+func (h *PromoCodeHandler) GetAllPromoCodes(c *gin.Context) {
+	list, err := h.uc.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.PromoCode, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *PromoCodeHandler) GetPromoCodeByID(c *gin.Context) {
+	id := c.Param("id")
+	p, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+func (h *PromoCodeHandler) CreatePromoCode(c *gin.Context) {
+	var in entities.PromoCode
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.uc.Create(c.Request.Context(), &in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, in)
+}
+
+func (h *PromoCodeHandler) UpdatePromoCode(c *gin.Context) {
+	id := c.Param("id")
+	var in entities.PromoCode
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.ID = id
+	if err := h.uc.Update(c.Request.Context(), &in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PromoCodeHandler) DeletePromoCode(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
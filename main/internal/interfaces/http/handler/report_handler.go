@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+)
+
+// ReportHandler handles HTTP requests for club reports.
+type ReportHandler struct {
+	uc     usecase.ReportUseCase
+	clubUC usecase.ClubUseCase
+}
+
+// NewReportHandler creates a new ReportHandler with injected use cases.
+func NewReportHandler(uc usecase.ReportUseCase, clubUC usecase.ClubUseCase) *ReportHandler {
+	return &ReportHandler{uc: uc, clubUC: clubUC}
+}
+
+// clubLocation returns the club's time zone, in which YYYY-MM-DD dates are
+// read so "today" means the club's day.
+func (h *ReportHandler) clubLocation(c *gin.Context) (*time.Location, bool) {
+	club, err := h.clubUC.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "club not found"})
+		return nil, false
+	}
+	return club.TZ(), true
+}
+
+// GetRevenue expects from and to as RFC 3339 timestamps or YYYY-MM-DD dates
+// in the club's time zone.
+func (h *ReportHandler) GetRevenue(c *gin.Context) {
+	loc, ok := h.clubLocation(c)
+	if !ok {
+		return
+	}
+	from, err := parseTimeParam(c.Query("from"), loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"), loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+		return
+	}
+	rep, err := h.uc.Revenue(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
+
+// GetCashReport is the end-of-shift count of offline payments. staff_id
+// narrows it to one staff member; from and to default to the last 12 hours.
+func (h *ReportHandler) GetCashReport(c *gin.Context) {
+	loc, ok := h.clubLocation(c)
+	if !ok {
+		return
+	}
+	to := time.Now()
+	from := to.Add(-12 * time.Hour)
+	var err error
+	if s := c.Query("from"); s != "" {
+		if from, err = parseTimeParam(s, loc); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+			return
+		}
+	}
+	if s := c.Query("to"); s != "" {
+		if to, err = parseTimeParam(s, loc); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+			return
+		}
+	}
+	rep, err := h.uc.Cash(c.Request.Context(), c.Param("id"), c.Query("staff_id"), from, to)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rep)
+}
+
+func parseTimeParam(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", s, loc)
+}
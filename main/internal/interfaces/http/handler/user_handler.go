@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// UserHandler handles HTTP requests for user profiles.
+type UserHandler struct {
+	uc usecase.UserUseCase
+}
+
+// NewUserHandler creates a new UserHandler with injected use case.
+func NewUserHandler(uc usecase.UserUseCase) *UserHandler {
+	return &UserHandler{uc: uc}
+}
+
+// GetMe returns the caller's profile, creating an empty one if needed.
+func (h *UserHandler) GetMe(c *gin.Context) {
+	user, err := h.uc.Ensure(c.Request.Context(), &entities.User{ID: c.GetString("uid")})
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateMe changes the fields present in the body.
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var patch entities.UserPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := h.uc.Update(c.Request.Context(), c.GetString("uid"), &patch)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// GetUser returns any user's profile for staff.
+func (h *UserHandler) GetUser(c *gin.Context) {
+	user, err := h.uc.Get(c.Request.Context(), c.Param("uid"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// RequestAgeVerification queues the caller for an ID check at the front desk.
+func (h *UserHandler) RequestAgeVerification(c *gin.Context) {
+	user, err := h.uc.RequestVerification(c.Request.Context(), c.GetString("uid"))
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// GetPendingVerifications lists users waiting for an ID check.
+func (h *UserHandler) GetPendingVerifications(c *gin.Context) {
+	list, err := h.uc.PendingVerifications(c.Request.Context())
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// VerifyAge records the outcome of checking a user's ID.
+func (h *UserHandler) VerifyAge(c *gin.Context) {
+	var in entities.VerificationReview
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := h.uc.Verify(c.Request.Context(), c.GetString("uid"), c.Param("uid"), &in)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// SetRole changes the role a user signs in with; staff are assigned the
+// club they work at.
+func (h *UserHandler) SetRole(c *gin.Context) {
+	var req struct {
+		Role   string `json:"role"`
+		ClubID string `json:"club_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.uc.SetRole(c.Request.Context(), c.Param("uid"), req.Role, req.ClubID); err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	if req.Role != entities.RoleStaff {
+		req.ClubID = ""
+	}
+	c.JSON(http.StatusOK, gin.H{"uid": c.Param("uid"), "role": req.Role, "club_id": req.ClubID})
+}
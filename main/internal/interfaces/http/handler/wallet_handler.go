@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/application/usecase"
+	"main/internal/domain/entities"
+)
+
+// WalletHandler handles HTTP requests for user wallets.
+type WalletHandler struct {
+	uc usecase.WalletUseCase
+}
+
+// NewWalletHandler creates a new WalletHandler with injected use case.
+func NewWalletHandler(uc usecase.WalletUseCase) *WalletHandler {
+	return &WalletHandler{uc: uc}
+}
+
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	userID := c.GetString("uid")
+	w, err := h.uc.Get(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, w)
+}
+
+func (h *WalletHandler) GetTransactions(c *gin.Context) {
+	userID := c.GetString("uid")
+	list, err := h.uc.GetTransactions(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.WalletTransaction, 0)
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *WalletHandler) TopUp(c *gin.Context) {
+	userID := c.GetString("uid")
+	var req struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	clientSecret, err := h.uc.CreateTopUpIntent(c.Request.Context(), userID, req.Amount, req.Currency)
+	if err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"clientSecret": clientSecret})
+}
+
+// GetUserWallet returns any user's wallet and ledger for staff.
+func (h *WalletHandler) GetUserWallet(c *gin.Context) {
+	userID := c.Param("uid")
+	w, err := h.uc.Get(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	list, err := h.uc.GetTransactions(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if list == nil {
+		list = make([]*entities.WalletTransaction, 0)
+	}
+	c.JSON(http.StatusOK, gin.H{"wallet": w, "transactions": list})
+}
+
+func (h *WalletHandler) Adjust(c *gin.Context) {
+	userID := c.Param("uid")
+	var req struct {
+		Amount float64 `json:"amount"`
+		Note   string  `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.uc.Adjust(c.Request.Context(), userID, req.Amount, c.GetString("uid"), req.Note); err != nil {
+		c.JSON(statusFor(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/domain/entities"
+)
+
+// ComputerFinder loads a computer by ID.
+type ComputerFinder interface {
+	GetByID(ctx context.Context, id string) (*entities.Computer, error)
+}
+
+// AgentMiddleware returns a Gin middleware for endpoints called by the
+// agents running on club PCs. It admits only agent API keys and stores the
+// key's computer under "computer". It must run after AuthMiddleware.
+func AgentMiddleware(computers ComputerFinder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p := CurrentPrincipal(c)
+		if p == nil || !p.HasScope(entities.ScopeAgent) || p.ComputerID == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Agent API key required"})
+			c.Abort()
+			return
+		}
+		comp, err := computers.GetByID(c.Request.Context(), p.ComputerID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Computer of this agent key not found"})
+			c.Abort()
+			return
+		}
+		c.Set("computer", comp)
+		c.Next()
+	}
+}
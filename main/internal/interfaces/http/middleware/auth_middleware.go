@@ -7,40 +7,84 @@ import (
 
 	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
+	"main/internal/domain/entities"
 )
 
-// AuthMiddleware returns a Gin middleware that verifies Firebase ID tokens.
-func AuthMiddleware(authClient *auth.Client) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		if authClient == nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication service not initialized"})
-			c.Abort()
-			return
-		}
+// APIKeyAuthenticator resolves a club API key to its principal.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*entities.Principal, error)
+}
 
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "No Authorization header"})
-			c.Abort()
-			return
+// AuthMiddleware returns a Gin middleware that accepts either a Firebase ID
+// token or a club API key, as a Bearer token or in the X-API-Key header.
+// The caller is stored as "principal", with "uid" and "role" kept for
+// handlers that only need those.
+func AuthMiddleware(authClient *auth.Client, keys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-API-Key")
+		if token == "" {
+			authHeader := c.GetHeader("Authorization")
+			if authHeader == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "No Authorization header"})
+				c.Abort()
+				return
+			}
+			token = strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
 		}
-
-		token := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
 		if token == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header"})
 			c.Abort()
 			return
 		}
 
-		decodedToken, err := authClient.VerifyIDToken(context.Background(), token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
+		var p *entities.Principal
+		if strings.HasPrefix(token, entities.APIKeyTag) {
+			var err error
+			if p, err = keys.Authenticate(c.Request.Context(), token); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+				c.Abort()
+				return
+			}
+		} else {
+			if authClient == nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication service not initialized"})
+				c.Abort()
+				return
+			}
+			decodedToken, err := authClient.VerifyIDToken(context.Background(), token)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+				c.Abort()
+				return
+			}
+			p = &entities.Principal{Kind: entities.PrincipalUser, UID: decodedToken.UID}
+			// role comes from the "role" custom claim, a staff member's
+			// club from "club_id"
+			if role, ok := decodedToken.Claims["role"].(string); ok {
+				p.Role = role
+			}
+			if clubID, ok := decodedToken.Claims["club_id"].(string); ok && p.Role == entities.RoleStaff {
+				p.ClubID = clubID
+			}
 		}
 
-		// store user's UID in context for downstream handlers
-		c.Set("uid", decodedToken.UID)
+		actor := entities.ActorFrom(c.Request.Context())
+		actor.Kind, actor.UID, actor.KeyID, actor.Role = p.Kind, p.UID, p.KeyID, p.Role
+		c.Request = c.Request.WithContext(entities.WithActor(c.Request.Context(), actor))
+
+		c.Set("principal", p)
+		c.Set("uid", p.UID)
+		if p.Role != "" {
+			c.Set("role", p.Role)
+		}
 		c.Next()
 	}
 }
+
+// CurrentPrincipal returns the caller stored by AuthMiddleware, or nil on
+// public routes.
+func CurrentPrincipal(c *gin.Context) *entities.Principal {
+	p, _ := c.Get("principal")
+	principal, _ := p.(*entities.Principal)
+	return principal
+}
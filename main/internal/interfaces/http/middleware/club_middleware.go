@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/domain/entities"
+)
+
+// ClubFinder loads a club by ID.
+type ClubFinder interface {
+	GetByID(ctx context.Context, id string) (*entities.Club, error)
+}
+
+// ClubResolver returns the ID of the club a request acts on.
+type ClubResolver func(c *gin.Context) (string, error)
+
+// ClubParam resolves routes whose :id is a club ID.
+func ClubParam(c *gin.Context) (string, error) {
+	return c.Param("id"), nil
+}
+
+// ComputerClub resolves routes whose :id is a computer ID to its club.
+func ComputerClub(computers ComputerFinder) ClubResolver {
+	return func(c *gin.Context) (string, error) {
+		comp, err := computers.GetByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return comp.ClubID, nil
+	}
+}
+
+// APIKeyFinder loads an API key by ID.
+type APIKeyFinder interface {
+	GetByID(ctx context.Context, id string) (*entities.APIKey, error)
+}
+
+// APIKeyClub resolves routes whose :id is an API key ID to its club.
+func APIKeyClub(keys APIKeyFinder) ClubResolver {
+	return func(c *gin.Context) (string, error) {
+		k, err := keys.GetByID(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			return "", err
+		}
+		return k.ClubID, nil
+	}
+}
+
+// RequireClubOwner returns a Gin middleware that admits admins and the
+// owner of the club resolved by clubOf. It must run after RequireRole.
+func RequireClubOwner(clubs ClubFinder, clubOf ClubResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clubID, err := clubOf(c)
+		if err != nil {
+			abortLookup(c, err)
+			return
+		}
+		if c.GetString("role") != RoleAdmin {
+			club, err := clubs.GetByID(c.Request.Context(), clubID)
+			if err != nil {
+				abortLookup(c, err)
+				return
+			}
+			if club.OwnerID == "" || club.OwnerID != c.GetString("uid") {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You do not own this club"})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireClubAccess returns a Gin middleware that refuses staff and API
+// keys of clubs other than the one resolved by clubOf. It must run after
+// AuthMiddleware.
+func RequireClubAccess(clubOf ClubResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p := CurrentPrincipal(c)
+		if p == nil || !p.ClubBound() {
+			c.Next()
+			return
+		}
+		clubID, err := clubOf(c)
+		if err != nil {
+			abortLookup(c, err)
+			return
+		}
+		if !p.CanAccessClub(clubID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not work at this club"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// abortLookup ends a request whose target could not be loaded.
+func abortLookup(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, entities.ErrClubNotFound) || errors.Is(err, entities.ErrComputerNotFound) ||
+		errors.Is(err, entities.ErrAPIKeyNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+	c.Abort()
+}
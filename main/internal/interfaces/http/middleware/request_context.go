@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/domain/entities"
+)
+
+// RequestIDHeader carries the request ID in and out.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds a request ID taken from a client or proxy.
+const maxRequestIDLength = 128
+
+// RequestContext gives each request an ID, reusing a valid X-Request-ID
+// header and echoing it in the response, and starts the audit actor with
+// the ID, client IP and route. AuthMiddleware adds the caller; requests it
+// does not see, such as webhooks, are attributed to the system.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		c.Set("request_id", id)
+		c.Header(RequestIDHeader, id)
+		ctx := entities.WithActor(c.Request.Context(), entities.Actor{
+			Kind:      entities.ActorSystem,
+			IP:        c.ClientIP(),
+			RequestID: id,
+			Route:     c.Request.Method + " " + c.FullPath(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
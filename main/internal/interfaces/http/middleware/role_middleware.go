@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"main/internal/domain/entities"
+)
+
+// Roles carried in the "role" custom claim of Firebase ID tokens.
+const (
+	RoleAdmin = entities.RoleAdmin
+	RoleOwner = entities.RoleOwner
+	RoleStaff = entities.RoleStaff
+)
+
+// RequireRole returns a Gin middleware that allows only callers whose role
+// (set by AuthMiddleware) is one of roles. API keys have no role and are
+// refused. It must run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+// RequireUser refuses API keys on routes that act for a signed-in user.
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if p := CurrentPrincipal(c); p == nil || p.Kind != entities.PrincipalUser {
+			c.JSON(http.StatusForbidden, gin.H{"error": "User sign-in required"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAccess is RequireRole that also admits API keys granted scope.
+// Keys are further limited to their club where a route names one.
+func RequireAccess(scope string, roles ...string) gin.HandlerFunc {
+	byRole := RequireRole(roles...)
+	return func(c *gin.Context) {
+		p := CurrentPrincipal(c)
+		if p == nil || p.Kind != entities.PrincipalAPIKey {
+			byRole(c)
+			return
+		}
+		if !p.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API key lacks the " + scope + " scope"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireClubParam refuses staff and API keys of other clubs on routes
+// whose :id is a club ID.
+func RequireClubParam() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if p := CurrentPrincipal(c); p != nil && !p.CanAccessClub(c.Param("id")) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not work at this club"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 	"main/internal/config"
+	"main/internal/domain/entities"
 	"main/internal/infrastructure/stripeclient"
 	"main/internal/interfaces/http/handler"
 	"main/internal/interfaces/http/middleware"
@@ -15,37 +16,170 @@ func NewRouter(
 	bookH *handler.BookingHandler,
 	authH *handler.AuthHandler,
 	paymentH *handler.PaymentHandler,
+	promoH *handler.PromoCodeHandler,
+	walletH *handler.WalletHandler,
+	memberH *handler.MembershipHandler,
+	loyaltyH *handler.LoyaltyHandler,
+	invoiceH *handler.InvoiceHandler,
+	reportH *handler.ReportHandler,
+	ledgerH *handler.PaymentLedgerHandler,
+	floorH *handler.FloorPlanHandler,
+	agentH *handler.AgentHandler,
+	commandH *handler.AgentCommandHandler,
+	keyH *handler.APIKeyHandler,
+	userH *handler.UserHandler,
+	banH *handler.BanHandler,
+	auditH *handler.AuditHandler,
+	keyAuth middleware.APIKeyAuthenticator,
+	computers middleware.ComputerFinder,
+	clubs middleware.ClubFinder,
+	apiKeys middleware.APIKeyFinder,
 	authClient *auth.Client,
 ) *gin.Engine {
-	// load config
-	config.Init()
-
 	// init stripeclient
 	stripeclient.Init(config.Cfg.Stripe.SecretKey)
 
 	r := gin.Default()
+	r.Use(middleware.RequestContext())
 
 	// Public routes
 	r.POST("/auth", authH.Auth)
 	r.GET("/clubs", clubH.GetAllClubs)
 	r.GET("/clubs/:id", clubH.GetClubByID)
 	r.GET("/computers", compH.GetAllComputers)
+	r.GET("/computers/:id", compH.GetComputer)
 	r.GET("/clubs/:id/computers", compH.GetClubComputers)
+	r.GET("/clubs/:id/floor-plan", floorH.GetFloorPlan)
+	r.GET("/clubs/:id/seat-map", floorH.GetSeatMap)
+	r.GET("/clubs/:id/plans", memberH.GetClubPlans)
+	r.GET("/clubs/:id/loyalty-config", loyaltyH.GetClubConfig)
 	r.POST("/payments/create", paymentH.CreateIntent)
 	r.POST("/webhook", paymentH.Webhook)
 
+	// PC agent routes
+	agent := r.Group("/agent", middleware.AuthMiddleware(authClient, keyAuth), middleware.AgentMiddleware(computers))
+	{
+		agent.POST("/heartbeat", agentH.Heartbeat)
+		agent.GET("/commands", commandH.PollCommands)
+		agent.POST("/commands/:id/ack", commandH.AckCommand)
+	}
+
 	// Protected routes
-	protected := r.Group("/", middleware.AuthMiddleware(authClient))
+	protected := r.Group("/", middleware.AuthMiddleware(authClient, keyAuth), middleware.RequireUser())
 	{
+		protected.GET("/me", userH.GetMe)
+		protected.PATCH("/me", userH.UpdateMe)
+		protected.POST("/me/age-verification", userH.RequestAgeVerification)
+
 		protected.POST("/clubs", clubH.CreateClub)
-		protected.PUT("/clubs/:id", clubH.UpdateClub)
-		protected.DELETE("/clubs/:id", clubH.DeleteClub)
 
 		protected.GET("/bookings", bookH.GetUserBookings)
+		protected.POST("/bookings/quote", bookH.QuoteBooking)
 		protected.POST("/bookings", bookH.CreateBooking)
 		protected.PUT("/bookings/:id/cancel", bookH.CancelBooking)
+		protected.POST("/bookings/:id/pay-wallet", bookH.PayWithWallet)
+		protected.POST("/bookings/:id/pay", bookH.CreatePaymentIntent)
+		protected.POST("/bookings/:id/checkout-session", bookH.CreateCheckoutSession)
+		protected.GET("/bookings/:id/receipt", invoiceH.GetReceipt)
+
+		protected.GET("/wallet", walletH.GetWallet)
+		protected.GET("/wallet/transactions", walletH.GetTransactions)
+		protected.POST("/wallet/topup", walletH.TopUp)
+
+		protected.GET("/memberships", memberH.GetUserMemberships)
+		protected.POST("/plans/:id/subscribe", memberH.Subscribe)
+		protected.POST("/memberships/:id/cancel", memberH.CancelMembership)
+
+		protected.GET("/loyalty", loyaltyH.GetAccounts)
+		protected.GET("/clubs/:id/loyalty", loyaltyH.GetClubAccount)
+		protected.GET("/loyalty/transactions", loyaltyH.GetTransactions)
+	}
+
+	// Admin routes
+	admin := r.Group("/admin", middleware.AuthMiddleware(authClient, keyAuth), middleware.RequireRole(middleware.RoleAdmin))
+	{
+		admin.GET("/promo-codes", promoH.GetAllPromoCodes)
+		admin.GET("/promo-codes/:id", promoH.GetPromoCodeByID)
+		admin.POST("/promo-codes", promoH.CreatePromoCode)
+		admin.PUT("/promo-codes/:id", promoH.UpdatePromoCode)
+		admin.DELETE("/promo-codes/:id", promoH.DeletePromoCode)
 
-		protected.POST("/clubs/:id/computers", compH.CreateComputerList)
+		admin.GET("/bookings/:id/ledger", ledgerH.GetBookingEntries)
+		admin.GET("/reconciliation/:date", ledgerH.GetReconciliation)
+		admin.POST("/reconciliation/run", ledgerH.RunReconciliation)
+
+		admin.GET("/bans", banH.GetGlobalBans)
+
+		admin.GET("/audit-log", auditH.GetAuditLog)
+		admin.GET("/deleted-clubs", clubH.GetDeletedClubs)
+		admin.POST("/deleted-clubs/:id/restore", clubH.RestoreClub)
+		admin.PUT("/clubs/:id/owner", clubH.SetClubOwner)
+		admin.PUT("/users/:uid/role", userH.SetRole)
+	}
+
+	// Club owner routes, limited to the caller's own clubs
+	owner := r.Group("/", middleware.AuthMiddleware(authClient, keyAuth), middleware.RequireRole(middleware.RoleOwner, middleware.RoleAdmin))
+	ownClub := middleware.RequireClubOwner(clubs, middleware.ClubParam)
+	ownComputer := middleware.RequireClubOwner(clubs, middleware.ComputerClub(computers))
+	ownKey := middleware.RequireClubOwner(clubs, middleware.APIKeyClub(apiKeys))
+	{
+		owner.PUT("/clubs/:id", ownClub, clubH.UpdateClub)
+		owner.POST("/clubs/:id/plans", ownClub, memberH.CreatePlan)
+		owner.PUT("/clubs/:id/plans/:planId", ownClub, memberH.UpdatePlan)
+		owner.PUT("/clubs/:id/loyalty-config", ownClub, loyaltyH.UpdateClubConfig)
+		owner.GET("/clubs/:id/reports/revenue", ownClub, reportH.GetRevenue)
+		owner.DELETE("/clubs/:id", ownClub, clubH.DeleteClub)
+		owner.PUT("/clubs/:id/hours", ownClub, clubH.SetHours)
+		owner.PUT("/clubs/:id/age-restriction", ownClub, clubH.SetAgeRestriction)
+		owner.DELETE("/clubs/:id/age-restriction", ownClub, clubH.DeleteAgeRestriction)
+		owner.PUT("/clubs/:id/no-show-policy", ownClub, clubH.SetNoShowPolicy)
+		owner.DELETE("/clubs/:id/no-show-policy", ownClub, clubH.DeleteNoShowPolicy)
+		owner.POST("/clubs/:id/computers", ownClub, compH.CreateComputerList)
+		owner.POST("/clubs/:id/computers/import", ownClub, compH.ImportComputers)
+		owner.PUT("/computers/:id", ownComputer, compH.UpdateComputer)
+		owner.DELETE("/computers/:id", ownComputer, compH.DeleteComputer)
+		owner.POST("/computers/:id/agent-token", ownComputer, agentH.IssueToken)
+		owner.POST("/clubs/:id/api-keys", ownClub, keyH.CreateAPIKey)
+		owner.GET("/clubs/:id/api-keys", ownClub, keyH.GetClubAPIKeys)
+		owner.POST("/api-keys/:id/rotate", ownKey, keyH.RotateAPIKey)
+		owner.DELETE("/api-keys/:id", ownKey, keyH.RevokeAPIKey)
+		owner.POST("/clubs/:id/closures", ownClub, clubH.AddClosure)
+		owner.PUT("/clubs/:id/floor-plan", ownClub, floorH.SaveFloorPlan)
+		owner.DELETE("/clubs/:id/floor-plan", ownClub, floorH.DeleteFloorPlan)
+	}
+
+	// Staff routes
+	staff := r.Group("/staff", middleware.AuthMiddleware(authClient, keyAuth), middleware.RequireRole(middleware.RoleStaff, middleware.RoleAdmin))
+	// staff only act on the club they work at
+	atComputer := middleware.RequireClubAccess(middleware.ComputerClub(computers))
+	// wallets span every club, so only admins read or credit them
+	adminOnly := middleware.RequireRole(middleware.RoleAdmin)
+	{
+		staff.GET("/users/:uid", userH.GetUser)
+		staff.GET("/age-verifications", userH.GetPendingVerifications)
+		staff.PUT("/users/:uid/age-verification", userH.VerifyAge)
+		staff.GET("/users/:uid/bans", banH.GetUserBans)
+		staff.GET("/clubs/:id/bans", middleware.RequireClubParam(), banH.GetClubBans)
+		staff.POST("/bans", banH.CreateBan)
+		staff.DELETE("/bans/:id", banH.LiftBan)
+		staff.GET("/wallets/:uid", adminOnly, walletH.GetUserWallet)
+		staff.POST("/wallets/:uid/adjust", adminOnly, walletH.Adjust)
+		staff.PUT("/bookings/:id/refund", bookH.RefundBooking)
+		staff.PUT("/computers/:id/maintenance", atComputer, compH.StartMaintenance)
+		staff.DELETE("/computers/:id/maintenance", atComputer, compH.EndMaintenance)
+		staff.POST("/computers/:id/commands", atComputer, commandH.SendCommand)
+		staff.GET("/computers/:id/commands", atComputer, commandH.GetCommands)
+		staff.PUT("/alerts/:id/resolve", agentH.ResolveAlert)
+	}
+
+	// Front-desk routes, open to staff and to kiosk API keys of the club
+	frontDesk := r.Group("/staff", middleware.AuthMiddleware(authClient, keyAuth), middleware.RequireAccess(entities.ScopeFrontDesk, middleware.RoleStaff, middleware.RoleAdmin))
+	{
+		frontDesk.PUT("/bookings/:id/check-in", bookH.CheckInBooking)
+		frontDesk.PUT("/bookings/:id/complete", bookH.CompleteBooking)
+		frontDesk.POST("/bookings/:id/offline-payment", bookH.RecordOfflinePayment)
+		frontDesk.GET("/clubs/:id/cash-report", middleware.RequireClubParam(), reportH.GetCashReport)
+		frontDesk.GET("/clubs/:id/alerts", middleware.RequireClubParam(), agentH.GetClubAlerts)
 	}
 	return r
 }